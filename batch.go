@@ -0,0 +1,129 @@
+package efp
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// BatchFormula directly maps a formula to parse in a batch, ID is the
+// identifier of the formula, such as the cell reference Sheet1!A1.
+type BatchFormula struct {
+	ID      string
+	Formula string
+}
+
+// BatchResult directly maps the parsed result of a formula in a batch. The
+// tokens and diagnostics are shared by the formulas with the identical text
+// and must not be modified.
+type BatchResult struct {
+	ID          string
+	Formula     string
+	Tokens      []Token
+	Diagnostics []Diagnostic
+}
+
+// BatchStats directly maps the aggregate statistics of a batch. Formulas is
+// the number of formulas, Unique is the number of distinct formula texts
+// which are parsed, Invalid is the number of formulas with syntax
+// diagnostics, Tokens is the total number of tokens of the formulas.
+type BatchStats struct {
+	Formulas int
+	Unique   int
+	Invalid  int
+	Tokens   int
+	Duration time.Duration
+}
+
+// BatchOptions directly maps the options of batch parsing. Workers is the
+// number of concurrent parsers, the number of CPUs if it's not positive.
+// Options is the parser options of all formulas.
+type BatchOptions struct {
+	Workers int
+	Options Options
+}
+
+// batchParsed directly maps the parsed result of a distinct formula text.
+type batchParsed struct {
+	formula     string
+	tokens      []Token
+	diagnostics []Diagnostic
+}
+
+// ParseBatch provides function to parse the formulas concurrently with the
+// optional batch options, and returns the results keyed by formula ID with
+// the aggregate statistics. Formulas with the identical text are parsed
+// once, a later formula replaces an earlier one with the same ID.
+func ParseBatch(formulas []BatchFormula, opts ...BatchOptions) (map[string]BatchResult, BatchStats) {
+	input := make(chan BatchFormula)
+	go func() {
+		defer close(input)
+		for _, f := range formulas {
+			input <- f
+		}
+	}()
+	return ParseBatchChan(input, opts...)
+}
+
+// ParseBatchChan provides function to parse the formulas received from the
+// channel until it's closed, as ParseBatch does.
+func ParseBatchChan(formulas <-chan BatchFormula, opts ...BatchOptions) (map[string]BatchResult, BatchStats) {
+	var options BatchOptions
+	if len(opts) > 0 {
+		options = opts[len(opts)-1]
+	}
+	if options.Workers <= 0 {
+		options.Workers = runtime.NumCPU()
+	}
+	var (
+		begin   = time.Now()
+		jobs    = make(chan string, options.Workers)
+		results = make(chan batchParsed, options.Workers)
+		workers sync.WaitGroup
+		parsed  = make(map[string]batchParsed)
+		done    = make(chan struct{})
+	)
+	for i := 0; i < options.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			ps := ExcelParser(options.Options)
+			for formula := range jobs {
+				tokens, diagnostics := ps.ParseWithErrors(formula)
+				results <- batchParsed{formula: formula, tokens: tokens, diagnostics: diagnostics}
+			}
+		}()
+	}
+	go func() {
+		for p := range results {
+			parsed[p.formula] = p
+		}
+		close(done)
+	}()
+	ids := make(map[string]string)
+	seen := make(map[string]bool)
+	for f := range formulas {
+		ids[f.ID] = f.Formula
+		if !seen[f.Formula] {
+			seen[f.Formula] = true
+			jobs <- f.Formula
+		}
+	}
+	close(jobs)
+	workers.Wait()
+	close(results)
+	<-done
+
+	stats := BatchStats{Formulas: len(ids), Unique: len(parsed)}
+	batch := make(map[string]BatchResult, len(ids))
+	for id, formula := range ids {
+		p := parsed[formula]
+		batch[id] = BatchResult{ID: id, Formula: formula, Tokens: p.tokens, Diagnostics: p.diagnostics}
+		if len(p.diagnostics) > 0 {
+			stats.Invalid++
+		}
+		stats.Tokens += len(p.tokens)
+	}
+	stats.Duration = time.Since(begin)
+	return batch, stats
+}
@@ -0,0 +1,45 @@
+package efp
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseBatch(t *testing.T) {
+	var formulas []BatchFormula
+	for i := 1; i <= 100; i++ {
+		formulas = append(formulas, BatchFormula{ID: "A" + strconv.Itoa(i), Formula: "=SUM(B1:B" + strconv.Itoa(i%10+1) + ")"})
+	}
+	formulas = append(formulas, BatchFormula{ID: "C1", Formula: "=SUM(B1"}, BatchFormula{ID: "A1", Formula: "=1+2"})
+	results, stats := ParseBatch(formulas, BatchOptions{Workers: 4})
+	if stats.Formulas != 101 || stats.Unique != 12 || stats.Invalid != 1 || stats.Tokens != 99*3+3+2 {
+		t.Errorf("unexpected statistics %+v", stats)
+	}
+	if len(results) != 101 {
+		t.Fatalf("expected 101 results, got %d", len(results))
+	}
+	for id, result := range results {
+		if result.ID != id || !reflect.DeepEqual(result.Tokens, Tokenize(result.Formula)) {
+			t.Errorf("unexpected result %+v", result)
+		}
+	}
+	if result := results["A1"]; result.Formula != "=1+2" {
+		t.Errorf("expected =1+2, got %s", result.Formula)
+	}
+	if result := results["C1"]; len(result.Diagnostics) != 1 || result.Diagnostics[0].Type != DiagnosticUnbalancedParenthesis {
+		t.Errorf("unexpected diagnostics %+v", result.Diagnostics)
+	}
+
+	input := make(chan BatchFormula, 2)
+	input <- BatchFormula{ID: "A1", Formula: "1;2"}
+	input <- BatchFormula{ID: "A2", Formula: "=R1C1"}
+	close(input)
+	results, stats = ParseBatchChan(input, BatchOptions{Options: Options{Locale: LocaleEuropean, R1C1: true}})
+	if stats.Formulas != 2 || stats.Invalid != 0 || results["A1"].Tokens[1].TValue != "," || results["A2"].Tokens[0].TSubType != TokenSubTypeRange {
+		t.Errorf("unexpected results %+v, %+v", results, stats)
+	}
+	if results, stats = ParseBatch(nil); len(results) != 0 || stats.Formulas != 0 {
+		t.Errorf("unexpected results %+v, %+v", results, stats)
+	}
+}
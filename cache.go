@@ -0,0 +1,305 @@
+package efp
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+)
+
+// defaultCacheCapacity is the number of entries of a cache without the
+// capacity option.
+const defaultCacheCapacity = 1024
+
+// CacheOptions directly maps the options of a parsed formula cache. Capacity
+// is the maximum number of entries, the least recently used entry is evicted
+// when it's exceeded. R1C1 specifies that the formulas are keyed by their
+// form in the R1C1 notation relative to the cell, so that the copies of a
+// formula filled across cells share the same entry. Options is the parser
+// options of all formulas.
+type CacheOptions struct {
+	Capacity int
+	R1C1     bool
+	Options  Options
+}
+
+// CacheStats directly maps the metrics of a parsed formula cache. Hits and
+// Misses are the number of lookups found and not found in the cache,
+// Evictions is the number of evicted entries, Entries is the number of
+// entries in the cache.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// HitRate returns the ratio of the lookups found in the cache, zero without
+// lookups.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// cacheEntry directly maps a parsed formula of the cache. Tokens are in the
+// R1C1 notation for the R1C1 keyed caches, the tree is built on first use.
+type cacheEntry struct {
+	key         string
+	tokens      []Token
+	diagnostics []Diagnostic
+	once        sync.Once
+	tree        Node
+	err         error
+}
+
+// cacheScanner directly maps the reusable state of a lookup in the R1C1 keyed
+// caches, the lexer, the key and the token stream of the formula, and the
+// buffer of the reference in the R1C1 notation.
+type cacheScanner struct {
+	lexer  *Lexer
+	key    []byte
+	ref    []byte
+	tokens []Token
+}
+
+// Cache directly maps a least recently used cache of parsed formulas, which
+// is safe for concurrent use. The cached token streams and trees are shared
+// by all lookups of the same entry and must not be modified.
+type Cache struct {
+	mu       sync.Mutex
+	options  CacheOptions
+	entries  map[string]*list.Element
+	order    *list.List
+	stats    CacheStats
+	scanners sync.Pool
+}
+
+// NewCache provides function to create a parsed formula cache with the
+// optional cache options.
+func NewCache(opts ...CacheOptions) *Cache {
+	c := &Cache{entries: make(map[string]*list.Element), order: list.New()}
+	if len(opts) > 0 {
+		c.options = opts[len(opts)-1]
+	}
+	if c.options.Capacity <= 0 {
+		c.options.Capacity = defaultCacheCapacity
+	}
+	c.scanners.New = func() interface{} {
+		return &cacheScanner{lexer: NewLexer("", c.options.Options)}
+	}
+	return c
+}
+
+// scan provides function to tokenize the formula located in the cell and to
+// build its key in a single pass, without allocating memory for typical
+// formulas. The values are prefixed by their length, and the references are
+// written in the R1C1 notation relative to the cell, prefixed by the "@"
+// sign, with the sheet part as written in the formula.
+func (s *cacheScanner) scan(formula, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	s.key, s.tokens = s.key[:0], s.tokens[:0]
+	s.lexer.Reset(formula)
+	for t, ok := s.lexer.Next(); ok; t, ok = s.lexer.Next() {
+		s.tokens = append(s.tokens, t)
+		s.key = append(s.key, t.TType...)
+		s.key = append(s.key, 0)
+		s.key = append(s.key, t.TSubType...)
+		s.key = append(s.key, 0)
+		value := t.TValue
+		if t.TType == TokenTypeOperand && t.TSubType == TokenSubTypeRange {
+			if ref, ok := parseReference(t.TValue); ok {
+				s.ref = s.ref[:0]
+				if sheet, _, ok := splitSheet(t.TValue); ok {
+					s.ref = append(append(s.ref, sheet...), '!')
+				}
+				s.ref = ref.appendR1C1Cells(s.ref, col, row)
+				s.key = append(s.key, '@')
+				s.key = strconv.AppendInt(s.key, int64(len(s.ref)), 10)
+				s.key = append(s.key, 0)
+				s.key = append(s.key, s.ref...)
+				continue
+			}
+		}
+		s.key = strconv.AppendInt(s.key, int64(len(value)), 10)
+		s.key = append(s.key, 0)
+		s.key = append(s.key, value...)
+	}
+	return nil
+}
+
+// entry provides function to get the cache entry of the formula located in
+// the cell, the formula is parsed and added to the cache if it's not found.
+// The formula itself is the key unless the scanner of the R1C1 keyed caches
+// is given.
+func (c *Cache) entry(formula, cell string, s *cacheScanner) *cacheEntry {
+	var (
+		el *list.Element
+		ok bool
+	)
+	c.mu.Lock()
+	if s == nil {
+		el, ok = c.entries[formula]
+	} else {
+		el, ok = c.entries[string(s.key)]
+	}
+	if ok {
+		c.order.MoveToFront(el)
+		c.stats.Hits++
+		c.mu.Unlock()
+		return el.Value.(*cacheEntry)
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	ps := ExcelParser(c.options.Options)
+	e := &cacheEntry{key: formula}
+	e.tokens, e.diagnostics = ps.ParseWithErrors(formula)
+	if s != nil {
+		e.key = string(s.key)
+		e.tokens, _ = ToR1C1(e.tokens, cell)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[e.key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*cacheEntry)
+	}
+	c.entries[e.key] = c.order.PushFront(e)
+	for c.order.Len() > c.options.Capacity {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.entries, el.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+	return e
+}
+
+// build provides function to get the cached tree of the entry, which is
+// built on first use.
+func (e *cacheEntry) build() (Node, error) {
+	e.once.Do(func() {
+		e.tree, e.err = BuildTree(e.tokens)
+	})
+	return e.tree, e.err
+}
+
+// anchor returns a copy of the tree of the entry with the tokens replaced by
+// the formula tokens at the same positions of the token stream, so that the
+// references of the R1C1 keyed caches are in the A1 notation for the cell.
+// The nodes are visited in the order of their tokens in the stream, and the
+// position of the next token is tracked by the index.
+func (e *cacheEntry) anchor(node Node, tokens []Token, i *int) Node {
+	token := func(t Token) Token {
+		for *i < len(e.tokens) && e.tokens[*i].Span != t.Span {
+			*i++
+		}
+		if *i == len(e.tokens) {
+			return t
+		}
+		*i++
+		return tokens[*i-1]
+	}
+	switch n := node.(type) {
+	case *Operand:
+		return &Operand{Token: token(n.Token)}
+	case *UnaryOp:
+		operator := token(n.Operator)
+		return &UnaryOp{Operator: operator, Operand: e.anchor(n.Operand, tokens, i)}
+	case *PostfixOp:
+		operand := e.anchor(n.Operand, tokens, i)
+		return &PostfixOp{Operator: token(n.Operator), Operand: operand}
+	case *BinaryOp:
+		left := e.anchor(n.Left, tokens, i)
+		operator := token(n.Operator)
+		return &BinaryOp{Operator: operator, Left: left, Right: e.anchor(n.Right, tokens, i)}
+	case *FunctionCall:
+		fn := &FunctionCall{Token: token(n.Token), Arguments: make([]Node, len(n.Arguments))}
+		fn.Name = fn.Token.TValue
+		for j, arg := range n.Arguments {
+			if arg != nil {
+				fn.Arguments[j] = e.anchor(arg, tokens, i)
+			}
+		}
+		return fn
+	case *Subexpression:
+		return &Subexpression{Expression: e.anchor(n.Expression, tokens, i)}
+	case *ArrayLiteral:
+		array := &ArrayLiteral{Rows: make([][]Node, len(n.Rows))}
+		for r, row := range n.Rows {
+			array.Rows[r] = make([]Node, len(row))
+			for j, item := range row {
+				array.Rows[r][j] = e.anchor(item, tokens, i)
+			}
+		}
+		return array
+	}
+	return node
+}
+
+// Parse provides function to get the token stream of the formula located in
+// the cell from the cache, parsing the formula if it's not cached. The cell,
+// such as B2, is required for the R1C1 keyed caches only, which tokenize the
+// formula by a lexer to look it up and return its own tokens, so that the
+// values and spans are the ones of the formula rather than the cached one.
+func (c *Cache) Parse(formula, cell string) ([]Token, error) {
+	if !c.options.R1C1 {
+		return c.entry(formula, cell, nil).tokens, nil
+	}
+	s := c.scanners.Get().(*cacheScanner)
+	defer c.scanners.Put(s)
+	if err := s.scan(formula, cell); err != nil {
+		return nil, err
+	}
+	c.entry(formula, cell, s)
+	return append([]Token(nil), s.tokens...), nil
+}
+
+// Tree provides function to get the syntax tree of the formula located in
+// the cell from the cache, as Parse does. The trees are built on first use
+// and cached, the R1C1 keyed caches cache the tree in the R1C1 notation and
+// return a copy of it with the tokens of the formula. Formulas with syntax
+// errors return the first diagnostic of the formula as the error.
+func (c *Cache) Tree(formula, cell string) (Node, error) {
+	if !c.options.R1C1 {
+		e := c.entry(formula, cell, nil)
+		if len(e.diagnostics) > 0 {
+			return nil, e.diagnostics[0]
+		}
+		return e.build()
+	}
+	s := c.scanners.Get().(*cacheScanner)
+	defer c.scanners.Put(s)
+	if err := s.scan(formula, cell); err != nil {
+		return nil, err
+	}
+	e := c.entry(formula, cell, s)
+	if len(e.diagnostics) > 0 {
+		// the spans of the cached diagnostics are located in the formula
+		// which was parsed on the miss
+		ps := ExcelParser(c.options.Options)
+		_, diagnostics := ps.ParseWithErrors(formula)
+		if len(diagnostics) > 0 {
+			return nil, diagnostics[0]
+		}
+		return nil, e.diagnostics[0]
+	}
+	tree, err := e.build()
+	if err != nil {
+		return nil, err
+	}
+	var i int
+	return e.anchor(tree, s.tokens, &i), nil
+}
+
+// Stats provides function to get the metrics of the cache.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
@@ -0,0 +1,147 @@
+package efp
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(CacheOptions{Capacity: 2})
+	tokens, err := c.Parse("=SUM(A1:A3)", "")
+	if err != nil || !reflect.DeepEqual(tokens, Tokenize("=SUM(A1:A3)")) {
+		t.Errorf("unexpected tokens %+v, %v", tokens, err)
+	}
+	cached, _ := c.Parse("=SUM(A1:A3)", "")
+	if &cached[0] != &tokens[0] {
+		t.Error("expected shared tokens")
+	}
+	tree, err := c.Tree("=SUM(A1:A3)", "")
+	if err != nil || tree.String() != "SUM(A1:A3)" {
+		t.Errorf("unexpected tree %v, %v", tree, err)
+	}
+	if cachedTree, _ := c.Tree("=SUM(A1:A3)", ""); cachedTree != tree {
+		t.Error("expected shared tree")
+	}
+	if _, err = c.Tree("=SUM(A1", ""); err == nil {
+		t.Error("expected error")
+	}
+	c.Parse("=1+2", "")
+	if stats := c.Stats(); stats.Hits != 3 || stats.Misses != 3 || stats.Evictions != 1 || stats.Entries != 2 || stats.HitRate() != 0.5 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if rate := (CacheStats{}).HitRate(); rate != 0 {
+		t.Errorf("expected zero hit rate, got %v", rate)
+	}
+}
+
+func TestCacheR1C1(t *testing.T) {
+	c := NewCache(CacheOptions{R1C1: true})
+	for row := 1; row <= 10; row++ {
+		formula := "=$A$1*B" + strconv.Itoa(row) + "+SUM(C1:C" + strconv.Itoa(row) + ")"
+		cell := "D" + strconv.Itoa(row)
+		tokens, err := c.Parse(formula, cell)
+		if err != nil || !reflect.DeepEqual(tokens, Tokenize(formula)) {
+			t.Errorf("%s: expected %+v, got %+v, %v", formula, Tokenize(formula), tokens, err)
+		}
+		tree, err := c.Tree(formula, cell)
+		if err != nil || tree.String() != formula[1:] {
+			t.Errorf("%s: unexpected tree %v, %v", formula, tree, err)
+		}
+	}
+	if stats := c.Stats(); stats.Misses != 10 || stats.Hits != 10 || stats.Entries != 10 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	c = NewCache(CacheOptions{R1C1: true})
+	for row := 2; row <= 10; row++ {
+		formula := "=B" + strconv.Itoa(row-1) + "+1"
+		if tokens, err := c.Parse(formula, "B"+strconv.Itoa(row)); err != nil || tokens[0].TValue != "B"+strconv.Itoa(row-1) || tokens[0].Span.End != len(formula)-2 {
+			t.Errorf("%s: unexpected tokens %+v, %v", formula, tokens, err)
+		}
+	}
+	if stats := c.Stats(); stats.Misses != 1 || stats.Hits != 8 || stats.Entries != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	c = NewCache(CacheOptions{R1C1: true})
+	for row := 1; row <= 3; row++ {
+		r := strconv.Itoa(row)
+		for _, formula := range []string{"=-b" + r + "%+(C" + r + "*{1,2;3,4})+IF(b" + r + ",,SUM($A$1:A" + r + "))", "=B" + r + "+SUM(1"} {
+			tokens, err := c.Parse(formula, "A"+r)
+			if err != nil || !reflect.DeepEqual(tokens, Tokenize(formula)) {
+				t.Errorf("%s: expected %+v, got %+v, %v", formula, Tokenize(formula), tokens, err)
+			}
+			ps := ExcelParser()
+			expected, expectedErr := ps.ParseTree(formula)
+			tree, err := c.Tree(formula, "A"+r)
+			if !reflect.DeepEqual(tree, expected) || !reflect.DeepEqual(err, expectedErr) {
+				t.Errorf("%s: expected %v, %v, got %v, %v", formula, expected, expectedErr, tree, err)
+			}
+		}
+	}
+	if stats := c.Stats(); stats.Misses != 2 || stats.Hits != 10 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if _, err := c.Parse("=A1", "A0"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := c.Tree("=A1", ""); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := c.Tree("=SUM(A1", "B1"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(CacheOptions{Capacity: 4, R1C1: true})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 1; i <= 50; i++ {
+				row := strconv.Itoa(i)
+				formula := "=A" + row + "*" + strconv.Itoa(g%6)
+				if tokens, err := c.Parse(formula, "B"+row); err != nil || tokens[0].TValue != "A"+row {
+					t.Errorf("%s: unexpected tokens %+v, %v", formula, tokens, err)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if stats := c.Stats(); stats.Hits+stats.Misses != 400 || stats.Entries != 4 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func BenchmarkCacheR1C1(b *testing.B) {
+	formula := `=IF(SUM(Sheet1!$A$1:$B$10,C1)>=100,ROUND(AVERAGE(D1:D5)*1.5%,2),"none")`
+	c := NewCache(CacheOptions{R1C1: true})
+	c.Parse(formula, "E1")
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			c.Parse(formula, "E1")
+		}
+	})
+	b.Run("Tree", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			c.Tree(formula, "E1")
+		}
+	})
+	b.Run("Tokenize", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Tokenize(formula)
+		}
+	})
+	b.Run("ParseTree", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ps := ExcelParser()
+			ps.ParseTree(formula)
+		}
+	})
+}
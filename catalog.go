@@ -0,0 +1,677 @@
+package efp
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Function argument and return kinds.
+const (
+	ArgumentAny       = "Any"
+	ArgumentNumber    = "Number"
+	ArgumentText      = "Text"
+	ArgumentLogical   = "Logical"
+	ArgumentReference = "Reference"
+	ArgumentArray     = "Array"
+)
+
+// FunctionSignature directly maps the signature of a worksheet function.
+// MinArgs and MaxArgs are the minimum and maximum number of arguments.
+// Arguments are the kinds of the arguments in order, the arguments after the
+// listed ones have the kind of the last one. Returns is the kind of the
+// result. Volatile reports whether the function is recalculated on every
+// change of the workbook. Version is the first Excel version providing the
+// function, 2003 for the functions of Excel 2003 and earlier.
+type FunctionSignature struct {
+	Name      string
+	MinArgs   int
+	MaxArgs   int
+	Arguments []string
+	Returns   string
+	Volatile  bool
+	Version   string
+}
+
+// signature returns the signature of a function.
+func signature(name string, minArgs, maxArgs int, returns, version string, args ...string) FunctionSignature {
+	return FunctionSignature{Name: name, MinArgs: minArgs, MaxArgs: maxArgs, Arguments: args, Returns: returns, Version: version}
+}
+
+// volatile returns the signature of a volatile function.
+func volatile(name string, minArgs, maxArgs int, returns, version string, args ...string) FunctionSignature {
+	s := signature(name, minArgs, maxArgs, returns, version, args...)
+	s.Volatile = true
+	return s
+}
+
+// functionCatalog directly maps the signatures of the built-in worksheet
+// functions by upper case name.
+var functionCatalog = buildFunctionCatalog(
+	signature("ABS", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ACCRINT", 6, 8, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("ACCRINTM", 4, 5, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("ACOS", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ACOSH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ACOT", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("ACOTH", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("ADDRESS", 2, 5, ArgumentText, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical, ArgumentText),
+	signature("AGGREGATE", 3, 255, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentReference),
+	signature("AMORDEGRC", 6, 7, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("AMORLINC", 6, 7, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("ANCHORARRAY", 1, 1, ArgumentArray, "2021", ArgumentReference),
+	signature("AND", 1, 255, ArgumentLogical, "2003", ArgumentLogical),
+	signature("ARABIC", 1, 1, ArgumentNumber, "2013", ArgumentText),
+	signature("AREAS", 1, 1, ArgumentNumber, "2003", ArgumentReference),
+	signature("ARRAYTOTEXT", 1, 2, ArgumentText, "2021", ArgumentArray, ArgumentNumber),
+	signature("ASC", 1, 1, ArgumentText, "2003", ArgumentText),
+	signature("ASIN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ASINH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ATAN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ATAN2", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("ATANH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("AVEDEV", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("AVERAGE", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("AVERAGEA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("AVERAGEIF", 2, 3, ArgumentNumber, "2007", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("AVERAGEIFS", 3, 255, ArgumentNumber, "2007", ArgumentReference, ArgumentReference, ArgumentAny),
+	signature("BAHTTEXT", 1, 1, ArgumentText, "2003", ArgumentNumber),
+	signature("BASE", 2, 3, ArgumentText, "2013", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("BESSELI", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("BESSELJ", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("BESSELK", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("BESSELY", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("BETA.DIST", 4, 6, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical, ArgumentNumber, ArgumentNumber),
+	signature("BETA.INV", 3, 5, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("BETADIST", 3, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("BETAINV", 3, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("BIN2DEC", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("BIN2HEX", 1, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("BIN2OCT", 1, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("BINOM.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("BINOM.DIST.RANGE", 3, 4, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("BINOM.INV", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("BINOMDIST", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("BITAND", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("BITLSHIFT", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("BITOR", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("BITRSHIFT", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("BITXOR", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("BYCOL", 2, 2, ArgumentArray, "365", ArgumentArray, ArgumentAny),
+	signature("BYROW", 2, 2, ArgumentArray, "365", ArgumentArray, ArgumentAny),
+	signature("CEILING", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("CEILING.MATH", 1, 3, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("CEILING.PRECISE", 1, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	volatile("CELL", 1, 2, ArgumentAny, "2003", ArgumentText, ArgumentReference),
+	signature("CHAR", 1, 1, ArgumentText, "2003", ArgumentNumber),
+	signature("CHIDIST", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("CHIINV", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("CHISQ.DIST", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("CHISQ.DIST.RT", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("CHISQ.INV", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("CHISQ.INV.RT", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("CHISQ.TEST", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentArray),
+	signature("CHITEST", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("CHOOSE", 2, 255, ArgumentAny, "2003", ArgumentNumber, ArgumentAny),
+	signature("CHOOSECOLS", 2, 255, ArgumentArray, "365", ArgumentArray, ArgumentNumber),
+	signature("CHOOSEROWS", 2, 255, ArgumentArray, "365", ArgumentArray, ArgumentNumber),
+	signature("CLEAN", 1, 1, ArgumentText, "2003", ArgumentText),
+	signature("CODE", 1, 1, ArgumentNumber, "2003", ArgumentText),
+	signature("COLUMN", 0, 1, ArgumentNumber, "2003", ArgumentReference),
+	signature("COLUMNS", 1, 1, ArgumentNumber, "2003", ArgumentArray),
+	signature("COMBIN", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("COMBINA", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("COMPLEX", 2, 3, ArgumentText, "2007", ArgumentNumber, ArgumentNumber, ArgumentText),
+	signature("CONCAT", 1, 254, ArgumentText, "2019", ArgumentAny),
+	signature("CONCATENATE", 1, 255, ArgumentText, "2003", ArgumentText),
+	signature("CONFIDENCE", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("CONFIDENCE.NORM", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("CONFIDENCE.T", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("CONVERT", 3, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentText, ArgumentText),
+	signature("CORREL", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("COS", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("COSH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("COT", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("COTH", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("COUNT", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("COUNTA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("COUNTBLANK", 1, 1, ArgumentNumber, "2003", ArgumentReference),
+	signature("COUNTIF", 2, 2, ArgumentNumber, "2003", ArgumentReference, ArgumentAny),
+	signature("COUNTIFS", 2, 255, ArgumentNumber, "2007", ArgumentReference, ArgumentAny),
+	signature("COUPDAYBS", 3, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("COUPDAYS", 3, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("COUPDAYSNC", 3, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("COUPNCD", 3, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("COUPNUM", 3, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("COUPPCD", 3, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("COVAR", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("COVARIANCE.P", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentArray),
+	signature("COVARIANCE.S", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentArray),
+	signature("CRITBINOM", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("CSC", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("CSCH", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("CUBEKPIMEMBER", 3, 4, ArgumentText, "2007", ArgumentText, ArgumentText, ArgumentNumber, ArgumentText),
+	signature("CUBEMEMBER", 2, 3, ArgumentText, "2007", ArgumentText, ArgumentAny, ArgumentText),
+	signature("CUBEMEMBERPROPERTY", 3, 3, ArgumentText, "2007", ArgumentText, ArgumentText, ArgumentText),
+	signature("CUBERANKEDMEMBER", 3, 4, ArgumentText, "2007", ArgumentText, ArgumentAny, ArgumentNumber, ArgumentText),
+	signature("CUBESET", 2, 5, ArgumentText, "2007", ArgumentText, ArgumentAny, ArgumentText, ArgumentNumber, ArgumentText),
+	signature("CUBESETCOUNT", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("CUBEVALUE", 1, 255, ArgumentAny, "2007", ArgumentText, ArgumentAny),
+	signature("CUMIPMT", 6, 6, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("CUMPRINC", 6, 6, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("DATE", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("DATEDIF", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentText),
+	signature("DATEVALUE", 1, 1, ArgumentNumber, "2003", ArgumentText),
+	signature("DAVERAGE", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DAY", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("DAYS", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("DAYS360", 2, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("DB", 4, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("DBCS", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("DCOUNT", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DCOUNTA", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DDB", 4, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("DEC2BIN", 1, 2, ArgumentText, "2007", ArgumentNumber, ArgumentNumber),
+	signature("DEC2HEX", 1, 2, ArgumentText, "2007", ArgumentNumber, ArgumentNumber),
+	signature("DEC2OCT", 1, 2, ArgumentText, "2007", ArgumentNumber, ArgumentNumber),
+	signature("DECIMAL", 2, 2, ArgumentNumber, "2013", ArgumentText, ArgumentNumber),
+	signature("DEGREES", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("DELTA", 1, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("DETECTLANGUAGE", 1, 1, ArgumentText, "365", ArgumentText),
+	signature("DEVSQ", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("DGET", 3, 3, ArgumentAny, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DISC", 4, 5, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("DMAX", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DMIN", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DOLLAR", 1, 2, ArgumentText, "2003", ArgumentNumber, ArgumentNumber),
+	signature("DOLLARDE", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("DOLLARFR", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("DPRODUCT", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DROP", 2, 3, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("DSTDEV", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DSTDEVP", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DSUM", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DURATION", 5, 6, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("DVAR", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("DVARP", 3, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("EDATE", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("EFFECT", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("ENCODEURL", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("EOMONTH", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("ERF", 1, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("ERF.PRECISE", 1, 1, ArgumentNumber, "2010", ArgumentNumber),
+	signature("ERFC", 1, 1, ArgumentNumber, "2007", ArgumentNumber),
+	signature("ERFC.PRECISE", 1, 1, ArgumentNumber, "2010", ArgumentNumber),
+	signature("ERROR.TYPE", 1, 1, ArgumentNumber, "2003", ArgumentAny),
+	signature("EVEN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("EXACT", 2, 2, ArgumentLogical, "2003", ArgumentText, ArgumentText),
+	signature("EXP", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("EXPAND", 2, 4, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentNumber, ArgumentAny),
+	signature("EXPON.DIST", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("EXPONDIST", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("F.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("F.DIST.RT", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("F.INV", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("F.INV.RT", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("F.TEST", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentArray),
+	signature("FACT", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("FACTDOUBLE", 1, 1, ArgumentNumber, "2007", ArgumentNumber),
+	signature("FALSE", 0, 0, ArgumentLogical, "2003"),
+	signature("FDIST", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FIELDVALUE", 2, 2, ArgumentAny, "365", ArgumentAny, ArgumentText),
+	signature("FILTER", 2, 3, ArgumentArray, "2021", ArgumentArray, ArgumentArray, ArgumentAny),
+	signature("FILTERXML", 2, 2, ArgumentAny, "2013", ArgumentText, ArgumentText),
+	signature("FIND", 2, 3, ArgumentNumber, "2003", ArgumentText, ArgumentText, ArgumentNumber),
+	signature("FINDB", 2, 3, ArgumentNumber, "2003", ArgumentText, ArgumentText, ArgumentNumber),
+	signature("FINV", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FISHER", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("FISHERINV", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("FIXED", 1, 3, ArgumentText, "2003", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("FLOOR", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("FLOOR.MATH", 1, 3, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FLOOR.PRECISE", 1, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("FORECAST", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentArray, ArgumentArray),
+	signature("FORECAST.ETS", 3, 6, ArgumentNumber, "2016", ArgumentNumber, ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FORECAST.ETS.CONFINT", 3, 7, ArgumentNumber, "2016", ArgumentNumber, ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FORECAST.ETS.SEASONALITY", 2, 4, ArgumentNumber, "2016", ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("FORECAST.ETS.STAT", 3, 6, ArgumentNumber, "2016", ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FORECAST.LINEAR", 3, 3, ArgumentNumber, "2016", ArgumentNumber, ArgumentArray, ArgumentArray),
+	signature("FORMULATEXT", 1, 1, ArgumentText, "2013", ArgumentReference),
+	signature("FREQUENCY", 2, 2, ArgumentArray, "2003", ArgumentArray, ArgumentArray),
+	signature("FTEST", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("FV", 3, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("FVSCHEDULE", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentArray),
+	signature("GAMMA", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("GAMMA.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("GAMMA.INV", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("GAMMADIST", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("GAMMAINV", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("GAMMALN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("GAMMALN.PRECISE", 1, 1, ArgumentNumber, "2010", ArgumentNumber),
+	signature("GAUSS", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("GCD", 1, 255, ArgumentNumber, "2007", ArgumentNumber),
+	signature("GEOMEAN", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("GESTEP", 1, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("GETPIVOTDATA", 2, 254, ArgumentAny, "2003", ArgumentText, ArgumentReference, ArgumentText, ArgumentAny),
+	signature("GROUPBY", 3, 8, ArgumentArray, "365", ArgumentArray, ArgumentArray, ArgumentAny, ArgumentNumber, ArgumentNumber, ArgumentAny, ArgumentArray, ArgumentNumber),
+	signature("GROWTH", 1, 4, ArgumentArray, "2003", ArgumentArray, ArgumentArray, ArgumentArray, ArgumentLogical),
+	signature("HARMEAN", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("HEX2BIN", 1, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("HEX2DEC", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("HEX2OCT", 1, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("HLOOKUP", 3, 4, ArgumentAny, "2003", ArgumentAny, ArgumentArray, ArgumentNumber, ArgumentLogical),
+	signature("HOUR", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("HSTACK", 1, 254, ArgumentArray, "365", ArgumentArray),
+	signature("HYPERLINK", 1, 2, ArgumentAny, "2003", ArgumentText, ArgumentAny),
+	signature("HYPGEOM.DIST", 5, 5, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("HYPGEOMDIST", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("IF", 2, 3, ArgumentAny, "2003", ArgumentLogical, ArgumentAny, ArgumentAny),
+	signature("IFERROR", 2, 2, ArgumentAny, "2007", ArgumentAny, ArgumentAny),
+	signature("IFNA", 2, 2, ArgumentAny, "2013", ArgumentAny, ArgumentAny),
+	signature("IFS", 2, 254, ArgumentAny, "2019", ArgumentLogical, ArgumentAny),
+	signature("IMABS", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("IMAGE", 1, 5, ArgumentAny, "365", ArgumentText, ArgumentText, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("IMAGINARY", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("IMARGUMENT", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("IMCONJUGATE", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMCOS", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMCOSH", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMCOT", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMCSC", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMCSCH", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMDIV", 2, 2, ArgumentText, "2007", ArgumentText, ArgumentText),
+	signature("IMEXP", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMLN", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMLOG10", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMLOG2", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMPOWER", 2, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("IMPRODUCT", 1, 255, ArgumentText, "2007", ArgumentText),
+	signature("IMREAL", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("IMSEC", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMSECH", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMSIN", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMSINH", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("IMSQRT", 1, 1, ArgumentText, "2007", ArgumentText),
+	signature("IMSUB", 2, 2, ArgumentText, "2007", ArgumentText, ArgumentText),
+	signature("IMSUM", 1, 255, ArgumentText, "2007", ArgumentText),
+	signature("IMTAN", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("INDEX", 2, 4, ArgumentReference, "2003", ArgumentArray, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	volatile("INDIRECT", 1, 2, ArgumentReference, "2003", ArgumentText, ArgumentLogical),
+	volatile("INFO", 1, 1, ArgumentAny, "2003", ArgumentText),
+	signature("INT", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("INTERCEPT", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("INTRATE", 4, 5, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("IPMT", 4, 6, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("IRR", 1, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber),
+	signature("ISBLANK", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISERR", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISERROR", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISEVEN", 1, 1, ArgumentLogical, "2007", ArgumentNumber),
+	signature("ISFORMULA", 1, 1, ArgumentLogical, "2013", ArgumentReference),
+	signature("ISLOGICAL", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISNA", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISNONTEXT", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISNUMBER", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISO.CEILING", 1, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("ISODD", 1, 1, ArgumentLogical, "2007", ArgumentNumber),
+	signature("ISOMITTED", 1, 1, ArgumentLogical, "365", ArgumentAny),
+	signature("ISOWEEKNUM", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("ISPMT", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("ISREF", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("ISTEXT", 1, 1, ArgumentLogical, "2003", ArgumentAny),
+	signature("KURT", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("LAMBDA", 1, 254, ArgumentAny, "365", ArgumentAny),
+	signature("LARGE", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber),
+	signature("LCM", 1, 255, ArgumentNumber, "2007", ArgumentNumber),
+	signature("LEFT", 1, 2, ArgumentText, "2003", ArgumentText, ArgumentNumber),
+	signature("LEFTB", 1, 2, ArgumentText, "2003", ArgumentText, ArgumentNumber),
+	signature("LEN", 1, 1, ArgumentNumber, "2003", ArgumentText),
+	signature("LENB", 1, 1, ArgumentNumber, "2003", ArgumentText),
+	signature("LET", 3, 253, ArgumentAny, "2021", ArgumentAny, ArgumentAny, ArgumentAny),
+	signature("LINEST", 1, 4, ArgumentArray, "2003", ArgumentArray, ArgumentArray, ArgumentLogical, ArgumentLogical),
+	signature("LN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("LOG", 1, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("LOG10", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("LOGEST", 1, 4, ArgumentArray, "2003", ArgumentArray, ArgumentArray, ArgumentLogical, ArgumentLogical),
+	signature("LOGINV", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("LOGNORM.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("LOGNORM.INV", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("LOGNORMDIST", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("LOOKUP", 2, 3, ArgumentAny, "2003", ArgumentAny, ArgumentArray, ArgumentArray),
+	signature("LOWER", 1, 1, ArgumentText, "2003", ArgumentText),
+	signature("MAKEARRAY", 3, 3, ArgumentArray, "365", ArgumentNumber, ArgumentNumber, ArgumentAny),
+	signature("MAP", 2, 254, ArgumentArray, "365", ArgumentArray, ArgumentAny),
+	signature("MATCH", 2, 3, ArgumentNumber, "2003", ArgumentAny, ArgumentArray, ArgumentNumber),
+	signature("MAX", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("MAXA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("MAXIFS", 3, 255, ArgumentNumber, "2019", ArgumentReference, ArgumentReference, ArgumentAny),
+	signature("MDETERM", 1, 1, ArgumentNumber, "2003", ArgumentArray),
+	signature("MDURATION", 5, 6, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("MEDIAN", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("MID", 3, 3, ArgumentText, "2003", ArgumentText, ArgumentNumber, ArgumentNumber),
+	signature("MIDB", 3, 3, ArgumentText, "2003", ArgumentText, ArgumentNumber, ArgumentNumber),
+	signature("MIN", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("MINA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("MINIFS", 3, 255, ArgumentNumber, "2019", ArgumentReference, ArgumentReference, ArgumentAny),
+	signature("MINUTE", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("MINVERSE", 1, 1, ArgumentArray, "2003", ArgumentArray),
+	signature("MIRR", 3, 3, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("MMULT", 2, 2, ArgumentArray, "2003", ArgumentArray, ArgumentArray),
+	signature("MOD", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("MODE", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("MODE.MULT", 1, 255, ArgumentArray, "2010", ArgumentNumber),
+	signature("MODE.SNGL", 1, 255, ArgumentNumber, "2010", ArgumentNumber),
+	signature("MONTH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("MROUND", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("MULTINOMIAL", 1, 255, ArgumentNumber, "2007", ArgumentNumber),
+	signature("MUNIT", 1, 1, ArgumentArray, "2013", ArgumentNumber),
+	signature("N", 1, 1, ArgumentNumber, "2003", ArgumentAny),
+	signature("NA", 0, 0, ArgumentAny, "2003"),
+	signature("NEGBINOM.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("NEGBINOMDIST", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("NETWORKDAYS", 2, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentArray),
+	signature("NETWORKDAYS.INTL", 2, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentAny, ArgumentArray),
+	signature("NOMINAL", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("NORM.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("NORM.INV", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("NORM.S.DIST", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentLogical),
+	signature("NORM.S.INV", 1, 1, ArgumentNumber, "2010", ArgumentNumber),
+	signature("NORMDIST", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("NORMINV", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("NORMSDIST", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("NORMSINV", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("NOT", 1, 1, ArgumentLogical, "2003", ArgumentLogical),
+	volatile("NOW", 0, 0, ArgumentNumber, "2003"),
+	signature("NPER", 3, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("NPV", 2, 255, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("NUMBERVALUE", 1, 3, ArgumentNumber, "2013", ArgumentText, ArgumentText, ArgumentText),
+	signature("OCT2BIN", 1, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("OCT2DEC", 1, 1, ArgumentNumber, "2007", ArgumentText),
+	signature("OCT2HEX", 1, 2, ArgumentText, "2007", ArgumentText, ArgumentNumber),
+	signature("ODD", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("ODDFPRICE", 8, 9, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("ODDFYIELD", 8, 9, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("ODDLPRICE", 7, 8, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("ODDLYIELD", 7, 8, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	volatile("OFFSET", 3, 5, ArgumentReference, "2003", ArgumentReference, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("OR", 1, 255, ArgumentLogical, "2003", ArgumentLogical),
+	signature("PDURATION", 3, 3, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("PEARSON", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("PERCENTILE", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber),
+	signature("PERCENTILE.EXC", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber),
+	signature("PERCENTILE.INC", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber),
+	signature("PERCENTOF", 2, 2, ArgumentNumber, "365", ArgumentArray, ArgumentArray),
+	signature("PERCENTRANK", 2, 3, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("PERCENTRANK.EXC", 2, 3, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("PERCENTRANK.INC", 2, 3, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("PERMUT", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("PERMUTATIONA", 2, 2, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber),
+	signature("PHI", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("PHONETIC", 1, 1, ArgumentText, "2003", ArgumentReference),
+	signature("PI", 0, 0, ArgumentNumber, "2003"),
+	signature("PIVOTBY", 4, 11, ArgumentArray, "365", ArgumentArray, ArgumentArray, ArgumentArray, ArgumentAny, ArgumentNumber, ArgumentNumber, ArgumentAny, ArgumentNumber, ArgumentAny, ArgumentArray, ArgumentNumber),
+	signature("PMT", 3, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("POISSON", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("POISSON.DIST", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("POWER", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("PPMT", 4, 6, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("PRICE", 6, 7, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("PRICEDISC", 4, 5, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("PRICEMAT", 5, 6, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("PROB", 3, 4, ArgumentNumber, "2003", ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("PRODUCT", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("PROPER", 1, 1, ArgumentText, "2003", ArgumentText),
+	signature("PV", 3, 5, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("QUARTILE", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber),
+	signature("QUARTILE.EXC", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber),
+	signature("QUARTILE.INC", 2, 2, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber),
+	signature("QUOTIENT", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("RADIANS", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	volatile("RAND", 0, 0, ArgumentNumber, "2003"),
+	volatile("RANDARRAY", 0, 5, ArgumentArray, "2021", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	volatile("RANDBETWEEN", 2, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("RANK", 2, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentReference, ArgumentNumber),
+	signature("RANK.AVG", 2, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentReference, ArgumentNumber),
+	signature("RANK.EQ", 2, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentReference, ArgumentNumber),
+	signature("RATE", 3, 6, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("RECEIVED", 4, 5, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("REDUCE", 3, 3, ArgumentAny, "365", ArgumentAny, ArgumentArray, ArgumentAny),
+	signature("REGEXEXTRACT", 2, 4, ArgumentAny, "365", ArgumentText, ArgumentText, ArgumentNumber, ArgumentNumber),
+	signature("REGEXREPLACE", 3, 5, ArgumentText, "365", ArgumentText, ArgumentText, ArgumentText, ArgumentNumber, ArgumentNumber),
+	signature("REGEXTEST", 2, 3, ArgumentLogical, "365", ArgumentText, ArgumentText, ArgumentNumber),
+	signature("REPLACE", 4, 4, ArgumentText, "2003", ArgumentText, ArgumentNumber, ArgumentNumber, ArgumentText),
+	signature("REPLACEB", 4, 4, ArgumentText, "2003", ArgumentText, ArgumentNumber, ArgumentNumber, ArgumentText),
+	signature("REPT", 2, 2, ArgumentText, "2003", ArgumentText, ArgumentNumber),
+	signature("RIGHT", 1, 2, ArgumentText, "2003", ArgumentText, ArgumentNumber),
+	signature("RIGHTB", 1, 2, ArgumentText, "2003", ArgumentText, ArgumentNumber),
+	signature("ROMAN", 1, 2, ArgumentText, "2003", ArgumentNumber, ArgumentNumber),
+	signature("ROUND", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("ROUNDDOWN", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("ROUNDUP", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("ROW", 0, 1, ArgumentNumber, "2003", ArgumentReference),
+	signature("ROWS", 1, 1, ArgumentNumber, "2003", ArgumentArray),
+	signature("RRI", 3, 3, ArgumentNumber, "2013", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("RSQ", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("RTD", 3, 255, ArgumentAny, "2003", ArgumentText, ArgumentText, ArgumentText),
+	signature("SCAN", 3, 3, ArgumentArray, "365", ArgumentAny, ArgumentArray, ArgumentAny),
+	signature("SEARCH", 2, 3, ArgumentNumber, "2003", ArgumentText, ArgumentText, ArgumentNumber),
+	signature("SEARCHB", 2, 3, ArgumentNumber, "2003", ArgumentText, ArgumentText, ArgumentNumber),
+	signature("SEC", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("SECH", 1, 1, ArgumentNumber, "2013", ArgumentNumber),
+	signature("SECOND", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SEQUENCE", 1, 4, ArgumentArray, "2021", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("SERIESSUM", 4, 4, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentArray),
+	signature("SHEET", 0, 1, ArgumentNumber, "2013", ArgumentAny),
+	signature("SHEETS", 0, 1, ArgumentNumber, "2013", ArgumentReference),
+	signature("SIGN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SIN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SINGLE", 1, 1, ArgumentAny, "2021", ArgumentAny),
+	signature("SINH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SKEW", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SKEW.P", 1, 255, ArgumentNumber, "2013", ArgumentNumber),
+	signature("SLN", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("SLOPE", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("SMALL", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber),
+	signature("SORT", 1, 4, ArgumentArray, "2021", ArgumentArray, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("SORTBY", 2, 254, ArgumentArray, "2021", ArgumentArray, ArgumentArray, ArgumentNumber),
+	signature("SQRT", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SQRTPI", 1, 1, ArgumentNumber, "2007", ArgumentNumber),
+	signature("STANDARDIZE", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("STDEV", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("STDEV.P", 1, 255, ArgumentNumber, "2010", ArgumentNumber),
+	signature("STDEV.S", 1, 254, ArgumentNumber, "2010", ArgumentNumber),
+	signature("STDEVA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("STDEVP", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("STDEVPA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("STEYX", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("STOCKHISTORY", 2, 11, ArgumentArray, "365", ArgumentAny, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("SUBSTITUTE", 3, 4, ArgumentText, "2003", ArgumentText, ArgumentText, ArgumentText, ArgumentNumber),
+	signature("SUBTOTAL", 2, 255, ArgumentNumber, "2003", ArgumentNumber, ArgumentReference),
+	signature("SUM", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SUMIF", 2, 3, ArgumentNumber, "2003", ArgumentReference, ArgumentAny, ArgumentReference),
+	signature("SUMIFS", 3, 255, ArgumentNumber, "2007", ArgumentReference, ArgumentReference, ArgumentAny),
+	signature("SUMPRODUCT", 1, 255, ArgumentNumber, "2003", ArgumentArray),
+	signature("SUMSQ", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("SUMX2MY2", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("SUMX2PY2", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("SUMXMY2", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentArray),
+	signature("SWITCH", 3, 254, ArgumentAny, "2019", ArgumentAny, ArgumentAny, ArgumentAny),
+	signature("SYD", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("T", 1, 1, ArgumentText, "2003", ArgumentAny),
+	signature("T.DIST", 3, 3, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("T.DIST.2T", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("T.DIST.RT", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("T.INV", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("T.INV.2T", 2, 2, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber),
+	signature("T.TEST", 4, 4, ArgumentNumber, "2010", ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("TAKE", 2, 3, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("TAN", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("TANH", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("TBILLEQ", 3, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("TBILLPRICE", 3, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("TBILLYIELD", 3, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("TDIST", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("TEXT", 2, 2, ArgumentText, "2003", ArgumentAny, ArgumentText),
+	signature("TEXTAFTER", 2, 6, ArgumentText, "365", ArgumentText, ArgumentText, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentAny),
+	signature("TEXTBEFORE", 2, 6, ArgumentText, "365", ArgumentText, ArgumentText, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentAny),
+	signature("TEXTJOIN", 3, 252, ArgumentText, "2019", ArgumentText, ArgumentLogical, ArgumentText),
+	signature("TEXTSPLIT", 2, 6, ArgumentArray, "365", ArgumentText, ArgumentAny, ArgumentAny, ArgumentLogical, ArgumentNumber, ArgumentAny),
+	signature("TIME", 3, 3, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("TIMEVALUE", 1, 1, ArgumentNumber, "2003", ArgumentText),
+	signature("TINV", 2, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("TOCOL", 1, 3, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentLogical),
+	volatile("TODAY", 0, 0, ArgumentNumber, "2003"),
+	signature("TOROW", 1, 3, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentLogical),
+	signature("TRANSLATE", 1, 3, ArgumentText, "365", ArgumentText, ArgumentText, ArgumentText),
+	signature("TRANSPOSE", 1, 1, ArgumentArray, "2003", ArgumentArray),
+	signature("TREND", 1, 4, ArgumentArray, "2003", ArgumentArray, ArgumentArray, ArgumentArray, ArgumentLogical),
+	signature("TRIM", 1, 1, ArgumentText, "2003", ArgumentText),
+	signature("TRIMMEAN", 2, 2, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber),
+	signature("TRIMRANGE", 1, 3, ArgumentArray, "365", ArgumentReference, ArgumentNumber, ArgumentNumber),
+	signature("TRUE", 0, 0, ArgumentLogical, "2003"),
+	signature("TRUNC", 1, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("TTEST", 4, 4, ArgumentNumber, "2003", ArgumentArray, ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("TYPE", 1, 1, ArgumentNumber, "2003", ArgumentAny),
+	signature("UNICHAR", 1, 1, ArgumentText, "2013", ArgumentNumber),
+	signature("UNICODE", 1, 1, ArgumentNumber, "2013", ArgumentText),
+	signature("UNIQUE", 1, 3, ArgumentArray, "2021", ArgumentArray, ArgumentLogical, ArgumentLogical),
+	signature("UPPER", 1, 1, ArgumentText, "2003", ArgumentText),
+	signature("VALUE", 1, 1, ArgumentNumber, "2003", ArgumentText),
+	signature("VALUETOTEXT", 1, 2, ArgumentText, "2021", ArgumentAny, ArgumentNumber),
+	signature("VAR", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("VAR.P", 1, 255, ArgumentNumber, "2010", ArgumentNumber),
+	signature("VAR.S", 1, 255, ArgumentNumber, "2010", ArgumentNumber),
+	signature("VARA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("VARP", 1, 255, ArgumentNumber, "2003", ArgumentNumber),
+	signature("VARPA", 1, 255, ArgumentNumber, "2003", ArgumentAny),
+	signature("VDB", 5, 7, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("VLOOKUP", 3, 4, ArgumentAny, "2003", ArgumentAny, ArgumentArray, ArgumentNumber, ArgumentLogical),
+	signature("VSTACK", 1, 254, ArgumentArray, "365", ArgumentArray),
+	signature("WEBSERVICE", 1, 1, ArgumentText, "2013", ArgumentText),
+	signature("WEEKDAY", 1, 2, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber),
+	signature("WEEKNUM", 1, 2, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber),
+	signature("WEIBULL", 4, 4, ArgumentNumber, "2003", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("WEIBULL.DIST", 4, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentLogical),
+	signature("WORKDAY", 2, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentArray),
+	signature("WORKDAY.INTL", 2, 4, ArgumentNumber, "2010", ArgumentNumber, ArgumentNumber, ArgumentAny, ArgumentArray),
+	signature("WRAPCOLS", 2, 3, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentAny),
+	signature("WRAPROWS", 2, 3, ArgumentArray, "365", ArgumentArray, ArgumentNumber, ArgumentAny),
+	signature("XIRR", 2, 3, ArgumentNumber, "2007", ArgumentArray, ArgumentArray, ArgumentNumber),
+	signature("XLOOKUP", 3, 6, ArgumentAny, "2021", ArgumentAny, ArgumentArray, ArgumentArray, ArgumentAny, ArgumentNumber, ArgumentNumber),
+	signature("XMATCH", 2, 4, ArgumentNumber, "2021", ArgumentAny, ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("XNPV", 3, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentArray, ArgumentArray),
+	signature("XOR", 1, 254, ArgumentLogical, "2013", ArgumentLogical),
+	signature("YEAR", 1, 1, ArgumentNumber, "2003", ArgumentNumber),
+	signature("YEARFRAC", 2, 3, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("YIELD", 6, 7, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("YIELDDISC", 4, 5, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("YIELDMAT", 5, 6, ArgumentNumber, "2007", ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber, ArgumentNumber),
+	signature("Z.TEST", 2, 3, ArgumentNumber, "2010", ArgumentArray, ArgumentNumber, ArgumentNumber),
+	signature("ZTEST", 2, 3, ArgumentNumber, "2003", ArgumentArray, ArgumentNumber, ArgumentNumber),
+)
+
+// buildFunctionCatalog returns the function signatures by name.
+func buildFunctionCatalog(signatures ...FunctionSignature) map[string]FunctionSignature {
+	catalog := make(map[string]FunctionSignature, len(signatures))
+	for _, s := range signatures {
+		catalog[s.Name] = s
+	}
+	return catalog
+}
+
+// LookupFunction provides function to get the signature of a built-in
+// worksheet function by name, case insensitively and with or without the
+// "_xlfn." prefix.
+func LookupFunction(name string) (FunctionSignature, bool) {
+	s, ok := functionCatalog[canonicalFunctionName(name)]
+	return s, ok
+}
+
+// Functions provides function to get the signatures of all built-in
+// worksheet functions sorted by name.
+func Functions() []FunctionSignature {
+	signatures := make([]FunctionSignature, 0, len(functionCatalog))
+	for _, s := range functionCatalog {
+		signatures = append(signatures, s)
+	}
+	sort.Slice(signatures, func(i, j int) bool {
+		return signatures[i].Name < signatures[j].Name
+	})
+	return signatures
+}
+
+// functionCall directly maps a function call found in a token stream. Args is
+// the number of argument separators, empty reports whether the call has no
+// tokens between the parentheses.
+type functionCall struct {
+	start Token
+	args  int
+	empty bool
+}
+
+// checkFunctionCall returns the diagnostic of a function call, false for the
+// valid and custom functions.
+func checkFunctionCall(call functionCall, stop Token) (Diagnostic, bool) {
+	span := call.start.Span
+	span.End, span.ByteEnd = stop.Span.End, stop.Span.ByteEnd
+	name := canonicalFunctionName(call.start.TValue)
+	s, ok := functionCatalog[name]
+	if !ok {
+		functionRegistry.RLock()
+		_, ok = functionRegistry.functions[name]
+		functionRegistry.RUnlock()
+		if ok {
+			return Diagnostic{}, false
+		}
+		return Diagnostic{Type: DiagnosticUnknownFunction, Message: fmt.Sprintf("unknown function %q", call.start.TValue), Span: span}, true
+	}
+	args := call.args + 1
+	if call.empty {
+		args = 0
+	}
+	switch {
+	case args < s.MinArgs:
+		return Diagnostic{Type: DiagnosticTooFewArguments, Message: fmt.Sprintf("%s requires at least %d arguments, got %d", s.Name, s.MinArgs, args), Span: span}, true
+	case args > s.MaxArgs:
+		return Diagnostic{Type: DiagnosticTooManyArguments, Message: fmt.Sprintf("%s accepts at most %d arguments, got %d", s.Name, s.MaxArgs, args), Span: span}, true
+	}
+	return Diagnostic{}, false
+}
+
+// ValidateFunctions provides function to check the function calls of a token
+// stream against the built-in function catalog, and returns the diagnostics
+// of the unknown functions and the calls with a wrong number of arguments,
+// sorted by position. The functions registered by RegisterFunction are known
+// functions with any number of arguments.
+func ValidateFunctions(tokens []Token) []Diagnostic {
+	var (
+		diagnostics []Diagnostic
+		stack       []*functionCall
+	)
+	for _, t := range tokens {
+		if len(stack) > 0 && !(t.TSubType == TokenSubTypeStop && (t.TType == TokenTypeFunction || t.TType == TokenTypeSubexpression)) {
+			stack[len(stack)-1].empty = false
+		}
+		switch {
+		case (t.TType == TokenTypeFunction || t.TType == TokenTypeSubexpression) && t.TSubType == TokenSubTypeStart:
+			stack = append(stack, &functionCall{start: t, empty: true})
+		case (t.TType == TokenTypeFunction || t.TType == TokenTypeSubexpression) && t.TSubType == TokenSubTypeStop:
+			if len(stack) == 0 {
+				continue
+			}
+			call := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if call.start.TType != TokenTypeFunction || call.start.TValue == "ARRAY" || call.start.TValue == "ARRAYROW" {
+				continue
+			}
+			if d, ok := checkFunctionCall(*call, t); ok {
+				diagnostics = append(diagnostics, d)
+			}
+		case t.TType == TokenTypeArgument && len(stack) > 0:
+			stack[len(stack)-1].args++
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Span.Start < diagnostics[j].Span.Start
+	})
+	return diagnostics
+}
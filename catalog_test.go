@@ -0,0 +1,66 @@
+package efp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupFunction(t *testing.T) {
+	s, ok := LookupFunction("_xlfn.xlookup")
+	if !ok || s.Name != "XLOOKUP" || s.MinArgs != 3 || s.MaxArgs != 6 || s.Returns != ArgumentAny || s.Version != "2021" {
+		t.Errorf("unexpected signature %+v", s)
+	}
+	if s, ok = LookupFunction("NOW"); !ok || !s.Volatile || s.MaxArgs != 0 {
+		t.Errorf("unexpected signature %+v", s)
+	}
+	if _, ok = LookupFunction("FOO"); ok {
+		t.Error("expected unknown function")
+	}
+	functions := Functions()
+	for i, s := range functions {
+		if i > 0 && functions[i-1].Name >= s.Name {
+			t.Errorf("unsorted functions %s and %s", functions[i-1].Name, s.Name)
+		}
+		if s.MinArgs > s.MaxArgs || (s.MaxArgs > 0 && len(s.Arguments) == 0) || len(s.Arguments) > s.MaxArgs {
+			t.Errorf("invalid signature %+v", s)
+		}
+	}
+	for name := range functionRegistry.functions {
+		if _, ok := LookupFunction(name); !ok {
+			t.Errorf("missing signature of %s", name)
+		}
+	}
+}
+
+func TestValidateFunctions(t *testing.T) {
+	for _, c := range []struct {
+		formula  string
+		expected []Diagnostic
+	}{
+		{formula: `=SUM(A1:A3,{1,2;3,4},(B1,B2))+IF(A1,)+PI()+_xlfn.XLOOKUP(A1,B:B,C:C)+ROUND(-A1,2)`},
+		{formula: `=SUBTOTAL(9,A1:A10)+AGGREGATE(14,6,A1:A10,1)+_xlfn.STDEV.P(A:A)+_xlfn.VAR.S(A:A)+PERCENTILE(A:A,0.5)+_xlfn.PERCENTILE.INC(A:A,0.5)+QUARTILE(A:A,1)+_xlfn.RANK.EQ(A1,A:A)`},
+		{formula: `=MROUND(A1,5)+QUOTIENT(A1,2)+WEEKNUM(A1)+YEARFRAC(A1,B1)+NPER(0.1,-100,1000)+SUMSQ(A1:A3)+SUM(MMULT(A1:B2,C1:D2))+SUM(FREQUENCY(A:A,B1:B3))`},
+		{formula: `=_xlfn.LAMBDA(_xlpm.x,_xlpm.x+1)+ROWS(_xlfn.TEXTSPLIT(A1,","))+_xlfn.ANCHORARRAY(B1)`},
+		{formula: `=SUM()`, expected: []Diagnostic{
+			{Type: DiagnosticTooFewArguments, Message: "SUM requires at least 1 arguments, got 0", Span: Span{Start: 1, End: 6, ByteStart: 1, ByteEnd: 6}},
+		}},
+		{formula: `=ROUND(A1)+NOW(1)`, expected: []Diagnostic{
+			{Type: DiagnosticTooFewArguments, Message: "ROUND requires at least 2 arguments, got 1", Span: Span{Start: 1, End: 10, ByteStart: 1, ByteEnd: 10}},
+			{Type: DiagnosticTooManyArguments, Message: "NOW accepts at most 0 arguments, got 1", Span: Span{Start: 11, End: 17, ByteStart: 11, ByteEnd: 17}},
+		}},
+		{formula: `="é"&FOO(IF(A1))`, expected: []Diagnostic{
+			{Type: DiagnosticUnknownFunction, Message: `unknown function "FOO"`, Span: Span{Start: 5, End: 16, ByteStart: 6, ByteEnd: 17}},
+			{Type: DiagnosticTooFewArguments, Message: "IF requires at least 2 arguments, got 1", Span: Span{Start: 9, End: 15, ByteStart: 10, ByteEnd: 16}},
+		}},
+	} {
+		p := ExcelParser()
+		if actual := ValidateFunctions(p.Parse(c.formula)); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %+v, got %+v", c.formula, c.expected, actual)
+		}
+	}
+	RegisterFunction("TEST.VALIDATE", func(args []Value) Value { return Value{} })
+	p := ExcelParser()
+	if actual := ValidateFunctions(p.Parse(`=TEST.VALIDATE(1,2,3)`)); len(actual) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", actual)
+	}
+}
@@ -0,0 +1,59 @@
+package efp
+
+// offset provides function to shift the relative column and row of the cell
+// part of a reference, and returns false when it moves out of the sheet. The
+// row of whole column references and the column of whole row references are
+// kept.
+func (c *CellRef) offset(cols, rows int, wholeColumn, wholeRow bool) bool {
+	if !c.ColAbs && !wholeRow {
+		c.Col += cols
+	}
+	if !c.RowAbs && !wholeColumn {
+		c.Row += rows
+	}
+	return c.Col >= 1 && c.Col <= MaxColumns && c.Row >= 1 && c.Row <= TotalRows
+}
+
+// CopyFormula provides function to adjust the range operands of a token
+// stream for copying the formula from the source cell to the destination
+// cell, such as C2 to D5. The relative columns and rows are shifted by the
+// distance between the cells and the absolute ($) parts are kept, references
+// shifted off the edges of the sheet become #REF! errors.
+func CopyFormula(tokens []Token, source, destination string) ([]Token, error) {
+	col1, row1, err := CellNameToCoordinates(source)
+	if err != nil {
+		return nil, err
+	}
+	col2, row2, err := CellNameToCoordinates(destination)
+	if err != nil {
+		return nil, err
+	}
+	cols, rows := col2-col1, row2-row1
+	return convertReferences(tokens, func(value string) (string, bool, bool) {
+		ref, err := ParseReference(value)
+		if err != nil {
+			return value, false, false
+		}
+		if cols == 0 && rows == 0 {
+			return value, true, true
+		}
+		if !ref.Start.offset(cols, rows, ref.WholeColumn, ref.WholeRow) ||
+			!ref.End.offset(cols, rows, ref.WholeColumn, ref.WholeRow) {
+			return value, true, false
+		}
+		return ref.String(), true, true
+	}), nil
+}
+
+// SharedFormula provides function to get the formula of a cell in the range
+// of a shared formula, such as the formula stored once with the ref and si
+// attributes in the worksheet part of a XLSX file. The relative references
+// of the master formula located in the anchor cell are shifted to the target
+// cell.
+func SharedFormula(master, anchor, target string) (string, error) {
+	tokens, err := CopyFormula(Tokenize(master), anchor, target)
+	if err != nil {
+		return "", err
+	}
+	return renderTokens(tokens, LocaleEnglish), nil
+}
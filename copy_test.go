@@ -0,0 +1,56 @@
+package efp
+
+import "testing"
+
+func TestCopyFormula(t *testing.T) {
+	for _, c := range []struct {
+		formula, source, destination, expected string
+	}{
+		{`=A1+$A1+A$1+$A$1`, "C2", "D5", `B4+$A4+B$1+$A$1`},
+		{`=SUM(A1:B2)*Sheet2!$C3+Name`, "C2", "C3", `SUM(A2:B3)*Sheet2!$C4+Name`},
+		{`=SUM(B:B)+SUM(2:$3)+"A1"`, "C2", "E4", `SUM(D:D)+SUM(4:$3)+"A1"`},
+		{`=A1+B2+XFD2`, "B2", "A1", `#REF!+A1+XFC1`},
+		{`=SUM(A1048575:A1048576)+A$1048576+XFD1`, "A1", "B2", `SUM(#REF!)+B$1048576+#REF!`},
+		{`=[Book1.xlsx]Sheet1!A1+Table1[Sales]`, "A1", "A2", `[Book1.xlsx]Sheet1!A2+Table1[Sales]`},
+		{`=A1`, "D4", "D4", `A1`},
+	} {
+		p := ExcelParser()
+		tokens, err := CopyFormula(p.Parse(c.formula), c.source, c.destination)
+		if err != nil {
+			t.Errorf("%s: %v", c.formula, err)
+			continue
+		}
+		if actual := renderTokens(tokens, LocaleEnglish); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+	if _, err := CopyFormula(nil, "A0", "A1"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := CopyFormula(nil, "A1", "1"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSharedFormula(t *testing.T) {
+	for _, c := range []struct {
+		master, target, expected string
+	}{
+		{`SUM(A1:A3)*$B$1`, "C2", `SUM(A2:A4)*$B$1`},
+		{`IF(A1="B1",'A1 Data'!B2,Sheet1!C$1)`, "D1", `IF(B1="B1",'A1 Data'!C2,Sheet1!D$1)`},
+		{`A1&"A1"`, "C1", `A1&"A1"`},
+		{`A2+1`, "B1", `#REF!+1`},
+	} {
+		actual, err := SharedFormula(c.master, "C1", c.target)
+		if err != nil {
+			t.Errorf("%s: %v", c.master, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.master, c.expected, actual)
+		}
+	}
+	if _, err := SharedFormula(`A1`, "C1", "C"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,94 @@
+package efp
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Diagnostic types.
+const (
+	DiagnosticUnbalancedParenthesis = "UnbalancedParenthesis"
+	DiagnosticUnbalancedBrace       = "UnbalancedBrace"
+	DiagnosticUnterminatedString    = "UnterminatedString"
+	DiagnosticUnterminatedSheetName = "UnterminatedSheetName"
+	DiagnosticUnterminatedBracket   = "UnterminatedBracket"
+	DiagnosticUnknownError          = "UnknownError"
+	DiagnosticMisplacedSemicolon    = "MisplacedSemicolon"
+	DiagnosticUnexpectedToken       = "UnexpectedToken"
+	DiagnosticUnknownFunction       = "UnknownFunction"
+	DiagnosticTooFewArguments       = "TooFewArguments"
+	DiagnosticTooManyArguments      = "TooManyArguments"
+)
+
+// Diagnostic directly maps a syntax or function call problem found in a
+// formula. Type is one of the diagnostic types, Span is the location of the
+// problem in the original formula text.
+type Diagnostic struct {
+	Type    string
+	Message string
+	Span    Span
+}
+
+// Error returns the message of the diagnostic with its rune position.
+func (d Diagnostic) Error() string {
+	return fmt.Sprintf("%s at position %d", d.Message, d.Span.Start)
+}
+
+// diagnose provides function to record a diagnostic for the characters
+// between the given offsets.
+func (ps *Parser) diagnose(diagnosticType, message string, start, end int) {
+	ps.diagnostics = append(ps.diagnostics, Diagnostic{
+		Type:    diagnosticType,
+		Message: message,
+		Span:    ps.span(start, end),
+	})
+}
+
+// checkEOF provides function to record diagnostics for the unterminated
+// states and unbalanced stack items left at the end of the formula.
+func (ps *Parser) checkEOF(start int) {
+	switch {
+	case ps.InString:
+		ps.diagnose(DiagnosticUnterminatedString, "unterminated string", start, ps.Offset)
+	case ps.InPath:
+		ps.diagnose(DiagnosticUnterminatedSheetName, "unterminated quoted sheet name", start, ps.Offset)
+	case ps.InRange:
+		ps.diagnose(DiagnosticUnterminatedBracket, "unterminated bracket", start, ps.Offset)
+	case ps.InError:
+		ps.diagnose(DiagnosticUnknownError, fmt.Sprintf("unknown error value %q", string(ps.fRune[start:ps.Offset])), start, ps.Offset)
+	}
+	for _, t := range ps.TokenStack.Items {
+		if t.TValue == "ARRAYROW" {
+			continue
+		}
+		if t.TValue == "ARRAY" {
+			ps.diagnostics = append(ps.diagnostics, Diagnostic{Type: DiagnosticUnbalancedBrace, Message: "unclosed brace", Span: t.Span})
+			continue
+		}
+		ps.diagnostics = append(ps.diagnostics, Diagnostic{Type: DiagnosticUnbalancedParenthesis, Message: "unclosed parenthesis", Span: t.Span})
+	}
+}
+
+// checkTokens provides function to record diagnostics for the unknown tokens
+// and sort all diagnostics by position.
+func (ps *Parser) checkTokens(tokens []Token) {
+	for _, t := range tokens {
+		if t.TType == TokenTypeUnknown {
+			ps.diagnostics = append(ps.diagnostics, Diagnostic{
+				Type:    DiagnosticUnexpectedToken,
+				Message: fmt.Sprintf("unexpected %q", t.TValue),
+				Span:    t.Span,
+			})
+		}
+	}
+	sort.SliceStable(ps.diagnostics, func(i, j int) bool {
+		return ps.diagnostics[i].Span.Start < ps.diagnostics[j].Span.Start
+	})
+}
+
+// ParseWithErrors provides function to parse formula as a token stream
+// (list), and returns the syntax diagnostics found in the formula.
+func (ps *Parser) ParseWithErrors(formula string) ([]Token, []Diagnostic) {
+	tokens := ps.Parse(formula)
+	return tokens, ps.diagnostics
+}
@@ -0,0 +1,40 @@
+package efp
+
+import "testing"
+
+func TestParseWithErrors(t *testing.T) {
+	for _, c := range []struct {
+		formula, diagnosticType string
+		start, end              int
+	}{
+		{`=SUM())`, DiagnosticUnbalancedParenthesis, 6, 7},
+		{`=SUM((1)`, DiagnosticUnbalancedParenthesis, 1, 5},
+		{`={1,2`, DiagnosticUnbalancedBrace, 1, 2},
+		{`=1}`, DiagnosticUnbalancedBrace, 2, 3},
+		{`={1)}`, DiagnosticUnbalancedParenthesis, 3, 4},
+		{`="abc`, DiagnosticUnterminatedString, 1, 5},
+		{`='My Sheet!A1`, DiagnosticUnterminatedSheetName, 1, 13},
+		{`=Table1[Sales`, DiagnosticUnterminatedBracket, 1, 13},
+		{`=1+#FOO`, DiagnosticUnknownError, 3, 7},
+		{`=SUM(1;2)`, DiagnosticMisplacedSemicolon, 6, 7},
+		{`=a"b"`, DiagnosticUnexpectedToken, 1, 2},
+	} {
+		p := ExcelParser()
+		_, diagnostics := p.ParseWithErrors(c.formula)
+		if len(diagnostics) == 0 {
+			t.Errorf("%s: expected diagnostic %s", c.formula, c.diagnosticType)
+			continue
+		}
+		d := diagnostics[0]
+		if d.Type != c.diagnosticType || d.Span.Start != c.start || d.Span.End != c.end {
+			t.Errorf("%s: expected %s at [%d, %d), got %s at [%d, %d): %v", c.formula,
+				c.diagnosticType, c.start, c.end, d.Type, d.Span.Start, d.Span.End, d)
+		}
+	}
+	for _, formula := range []string{`=SUM(A1,{1,2;3,4})`, `="a""b"&'My Sheet'!A1`, `=#N/A`, ``} {
+		p := ExcelParser()
+		if _, diagnostics := p.ParseWithErrors(formula); len(diagnostics) != 0 {
+			t.Errorf("%s: unexpected diagnostics %v", formula, diagnostics)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package efp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sheet edit types.
+const (
+	EditInsertRows    = "InsertRows"
+	EditDeleteRows    = "DeleteRows"
+	EditInsertColumns = "InsertColumns"
+	EditDeleteColumns = "DeleteColumns"
+)
+
+// SheetEdit directly maps a row or column insertion or deletion in a sheet.
+// Type is one of the sheet edit types, Index is the 1-based number of the
+// first inserted or deleted row or column, and Count is the number of rows or
+// columns.
+type SheetEdit struct {
+	Type  string
+	Sheet string
+	Index int
+	Count int
+}
+
+// limit returns the maximum row or column number of the edited dimension.
+func (edit SheetEdit) limit() int {
+	if edit.Type == EditInsertColumns || edit.Type == EditDeleteColumns {
+		return MaxColumns
+	}
+	return TotalRows
+}
+
+// validate provides function to check the sheet edit type, index and count,
+// the edited rows or columns must be inside the sheet.
+func (edit SheetEdit) validate() error {
+	switch edit.Type {
+	case EditInsertRows, EditDeleteRows, EditInsertColumns, EditDeleteColumns:
+	default:
+		return fmt.Errorf("unsupported sheet edit type %q", edit.Type)
+	}
+	if edit.Index < 1 || edit.Index > edit.limit() || edit.Count < 1 || edit.Count > edit.limit()-edit.Index+1 {
+		return fmt.Errorf("invalid sheet edit index %d and count %d", edit.Index, edit.Count)
+	}
+	return nil
+}
+
+// shiftSpan returns the first and last row or column numbers of a span after
+// the edit, false when the whole span is deleted or moved out of the sheet.
+// Spans partially moved out of the sheet are truncated, spans partially
+// deleted shrink to the remaining rows or columns.
+func (edit SheetEdit) shiftSpan(first, last int) (int, int, bool) {
+	if edit.Type == EditInsertRows || edit.Type == EditInsertColumns {
+		if first >= edit.Index {
+			first += edit.Count
+		}
+		if last >= edit.Index {
+			last += edit.Count
+		}
+		return first, minInt(last, edit.limit()), first <= edit.limit()
+	}
+	end := edit.Index + edit.Count - 1
+	if first >= edit.Index && last <= end {
+		return first, last, false
+	}
+	if first > end {
+		first -= edit.Count
+	} else if first >= edit.Index {
+		first = edit.Index
+	}
+	if last > end {
+		last -= edit.Count
+	} else if last >= edit.Index {
+		last = edit.Index - 1
+	}
+	return first, last, true
+}
+
+// shiftPart provides function to shift the start and end row or column
+// numbers of a reference in the same order as written.
+func (edit SheetEdit) shiftPart(start, end *int) bool {
+	first, last := *start, *end
+	if first > last {
+		first, last = last, first
+	}
+	first, last, ok := edit.shiftSpan(first, last)
+	if *start > *end {
+		first, last = last, first
+	}
+	*start, *end = first, last
+	return ok
+}
+
+// shift provides function to update the reference for the edit, and returns
+// false when the reference becomes invalid.
+func (edit SheetEdit) shift(r *Reference) bool {
+	if edit.Type == EditInsertColumns || edit.Type == EditDeleteColumns {
+		return r.WholeRow || edit.shiftPart(&r.Start.Col, &r.End.Col)
+	}
+	return r.WholeColumn || edit.shiftPart(&r.Start.Row, &r.End.Row)
+}
+
+// ApplySheetEdit provides function to update the range operands of the parsed
+// formula located in the given sheet for a row or column insertion or
+// deletion, and get the formula text after updated. The references to the
+// deleted cells become #REF! errors with the sheet prefix as written, such as
+// Sheet1!#REF!, the references to external workbooks and 3D references are
+// kept. The formulas parsed in the R1C1 notation are unsupported, since their
+// relative references depend on the cell of the formula.
+func (ps *Parser) ApplySheetEdit(sheet string, edit SheetEdit) (string, error) {
+	if err := edit.validate(); err != nil {
+		return "", err
+	}
+	if ps.options.R1C1 {
+		return "", fmt.Errorf("unsupported sheet edit of formula in the R1C1 notation")
+	}
+	ps.Tokens.Items = convertReferences(ps.Tokens.Items, func(value string) (string, bool, bool) {
+		ref, err := ParseReference(value)
+		if err != nil || ref.Workbook != "" || ref.LastSheet != "" {
+			return value, false, false
+		}
+		refSheet := ref.Sheet
+		if refSheet == "" {
+			refSheet = sheet
+		}
+		if !strings.EqualFold(refSheet, edit.Sheet) {
+			return value, false, false
+		}
+		if !edit.shift(&ref) {
+			return value, true, false
+		}
+		return ref.String(), true, true
+	})
+	return ps.Render(), nil
+}
@@ -0,0 +1,70 @@
+package efp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApplySheetEdit(t *testing.T) {
+	for _, c := range []struct {
+		formula  string
+		edit     SheetEdit
+		expected string
+	}{
+		{`=SUM(A1:A5)+B3*$C$10`, SheetEdit{Type: EditInsertRows, Sheet: "Sheet1", Index: 3, Count: 2}, `SUM(A1:A7)+B5*$C$12`},
+		{`=SUM(A1:A5)+A6`, SheetEdit{Type: EditInsertRows, Sheet: "Sheet1", Index: 6, Count: 1}, `SUM(A1:A5)+A7`},
+		{`=Sheet2!A3+sheet1!A3+Other!A3`, SheetEdit{Type: EditInsertRows, Sheet: "Sheet2", Index: 1, Count: 1}, `Sheet2!A4+sheet1!A3+Other!A3`},
+		{`=SUM(3:7)+SUM(B:B)+A1048576`, SheetEdit{Type: EditInsertRows, Sheet: "Sheet1", Index: 5, Count: 1}, `SUM(3:8)+SUM(B:B)+#REF!`},
+		{`=SUM(A1048570:A1048575)`, SheetEdit{Type: EditInsertRows, Sheet: "Sheet1", Index: 1, Count: 3}, `SUM(A1048573:A1048576)`},
+		{`=A1048576+A1`, SheetEdit{Type: EditDeleteRows, Sheet: "Sheet1", Index: TotalRows, Count: 1}, `#REF!+A1`},
+		{`=A4+A8+A10+SUM(A3:A7)+SUM(A6:A12)+SUM(A5:A9)`, SheetEdit{Type: EditDeleteRows, Sheet: "sheet1", Index: 5, Count: 5},
+			`A4+#REF!+A5+SUM(A3:A4)+SUM(A5:A7)+SUM(#REF!)`},
+		{`=SUM(C1:A2)+$D$1+SUM(1:1)+"B1"`, SheetEdit{Type: EditDeleteColumns, Sheet: "Sheet1", Index: 2, Count: 1}, `SUM(B1:A2)+$C$1+SUM(1:1)+"B1"`},
+		{`=SUM(B:D)+E1+Name`, SheetEdit{Type: EditInsertColumns, Sheet: "Sheet1", Index: 3, Count: 2}, `SUM(B:F)+G1+Name`},
+		{`=Sheet1!A2+'My Sheet'!B2+SUM(sheet1!A1:A2)`, SheetEdit{Type: EditDeleteRows, Sheet: "Sheet1", Index: 1, Count: 2},
+			`Sheet1!#REF!+'My Sheet'!B2+SUM(sheet1!#REF!)`},
+		{`='My Sheet'!B2+'My Sheet'!C2`, SheetEdit{Type: EditDeleteColumns, Sheet: "My Sheet", Index: 2, Count: 1}, `'My Sheet'!#REF!+'My Sheet'!B2`},
+		{`=[Book1.xlsx]Sheet1!A5+Sheet1:Sheet3!A5`, SheetEdit{Type: EditDeleteRows, Sheet: "Sheet1", Index: 1, Count: 1}, `[Book1.xlsx]Sheet1!A5+Sheet1:Sheet3!A5`},
+	} {
+		p := ExcelParser()
+		p.Parse(c.formula)
+		actual, err := p.ApplySheetEdit("Sheet1", c.edit)
+		if err != nil {
+			t.Errorf("%s: %v", c.formula, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+	p := ExcelParser()
+	p.Parse(`=A1`)
+	for _, edit := range []SheetEdit{
+		{Type: "MoveRows", Index: 1, Count: 1},
+		{Type: EditDeleteRows, Index: 0, Count: 1},
+		{Type: EditInsertColumns, Index: MaxColumns + 1, Count: 1},
+		{Type: EditInsertColumns, Index: 1},
+		{Type: EditInsertRows, Index: 2, Count: math.MaxInt64},
+		{Type: EditDeleteRows, Index: TotalRows, Count: 2},
+	} {
+		if _, err := p.ApplySheetEdit("Sheet1", edit); err == nil {
+			t.Errorf("%+v: expected error", edit)
+		}
+	}
+	p.Parse(`=Sheet2!A1+1`)
+	if _, err := p.ApplySheetEdit("Sheet1", SheetEdit{Type: EditDeleteRows, Sheet: "Sheet2", Index: 1, Count: 1}); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := BuildTree(p.Tokens.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := NewEvaluator("Sheet1", newTestResolver()).Evaluate(tree); err != nil || v.String() != FormulaErrorREF {
+		t.Errorf("expected #REF!, got %v, %v", v, err)
+	}
+	p = ExcelParser(Options{R1C1: true})
+	p.Parse(`=R[1]C+R2C2`)
+	if _, err := p.ApplySheetEdit("Sheet1", SheetEdit{Type: EditInsertRows, Sheet: "Sheet1", Index: 1, Count: 1}); err == nil {
+		t.Error("expected error")
+	}
+}
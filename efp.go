@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // QuoteDouble, QuoteSingle and other's constants are token definitions.
@@ -43,17 +45,19 @@ const (
 	TokenTypeUnknown         = "Unknown"
 
 	// Token subtypes
-	TokenSubTypeStart         = "Start"
-	TokenSubTypeStop          = "Stop"
-	TokenSubTypeText          = "Text"
-	TokenSubTypeNumber        = "Number"
-	TokenSubTypeLogical       = "Logical"
-	TokenSubTypeError         = "Error"
-	TokenSubTypeRange         = "Range"
-	TokenSubTypeMath          = "Math"
-	TokenSubTypeConcatenation = "Concatenation"
-	TokenSubTypeIntersection  = "Intersection"
-	TokenSubTypeUnion         = "Union"
+	TokenSubTypeStart               = "Start"
+	TokenSubTypeStop                = "Stop"
+	TokenSubTypeText                = "Text"
+	TokenSubTypeNumber              = "Number"
+	TokenSubTypeLogical             = "Logical"
+	TokenSubTypeError               = "Error"
+	TokenSubTypeRange               = "Range"
+	TokenSubTypeName                = "Name"
+	TokenSubTypeStructuredReference = "StructuredReference"
+	TokenSubTypeMath                = "Math"
+	TokenSubTypeConcatenation       = "Concatenation"
+	TokenSubTypeIntersection        = "Intersection"
+	TokenSubTypeUnion               = "Union"
 )
 
 var expRegex = regexp.MustCompile(`^[1-9]{1}(\.[0-9]+)?E{1}$`)
@@ -63,6 +67,17 @@ type Token struct {
 	TValue   string
 	TType    string
 	TSubType string
+	Span     Span
+}
+
+// Span directly maps the location of a token in the original formula text.
+// Start and End are rune offsets, ByteStart and ByteEnd are byte offsets, the
+// end offsets are exclusive.
+type Span struct {
+	Start     int
+	End       int
+	ByteStart int
+	ByteEnd   int
 }
 
 // Tokens directly maps the ordered list of tokens.
@@ -75,11 +90,29 @@ type Tokens struct {
 	Items []Token
 }
 
+// Options define the options for parsing formulas. DefinedNames specifies the
+// known defined names of the workbook, operands matching one of them case
+// insensitively, with or without a sheet prefix, are classified as names
+// instead of ranges. R1C1 specifies that references are written in the R1C1
+// notation, such as R1C1 or R[-1]C[2], instead of the A1 notation. Locale
+// specifies the separators of the formulas, the token values are always
+// converted to the English separators. Lossless specifies that Render
+// reproduces the parsed formula byte for byte, except for the changed
+// tokens.
+type Options struct {
+	DefinedNames []string
+	R1C1         bool
+	Locale       Locale
+	Lossless     bool
+}
+
 // Parser inheritable container. TokenStack directly maps a LIFO stack of
 // tokens.
 type Parser struct {
 	Formula    string
 	fRune      []rune
+	fPos       []int
+	fByte      []int
 	Tokens     Tokens
 	TokenStack Tokens
 	Offset     int
@@ -87,6 +120,12 @@ type Parser struct {
 	InPath     bool
 	InRange    bool
 	InError    bool
+
+	diagnostics []Diagnostic
+	options     Options
+	names       map[string]bool
+	depth       int
+	original    []Token
 }
 
 // isInComparisonSet matches <=, >=, and <>
@@ -108,30 +147,7 @@ func isInfix(r rune) bool {
 // isAnError returns a value that indicates whether the given runes text
 // represents a formula error.
 func isAnError(r []rune) bool {
-	return runesEqual(r, []rune("#NULL!")) ||
-		runesEqual(r, []rune("#DIV/0!")) ||
-		runesEqual(r, []rune("#VALUE!")) ||
-		runesEqual(r, []rune("#REF!")) ||
-		runesEqual(r, []rune("#NAME?")) ||
-		runesEqual(r, []rune("#NUM!")) ||
-		runesEqual(r, []rune("#N/A")) ||
-		runesEqual(r, []rune("#SPILL!")) ||
-		runesEqual(r, []rune("#CALC!")) ||
-		runesEqual(r, []rune("#GETTING_DATA"))
-}
-
-// runesEqual Returns a value that indicates whether the current runes text and
-// a specified runes text are equal.
-func runesEqual(a, b []rune) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i, r := range a {
-		if b[i] != r {
-			return false
-		}
-	}
-	return true
+	return isErrorValue(string(r))
 }
 
 // fToken provides function to encapsulate a formula token.
@@ -157,8 +173,9 @@ func fTokens(size, cap int) Tokens {
 }
 
 // add provides function to add a token to the end of the list.
-func (tk *Tokens) add(value, tokenType, subType string) Token {
+func (tk *Tokens) add(value, tokenType, subType string, span Span) Token {
 	token := fToken(value, tokenType, subType)
+	token.Span = span
 	tk.addRef(token)
 	return token
 }
@@ -268,21 +285,120 @@ func (tk *Tokens) subtype() string {
 }
 
 // ExcelParser provides function to parse an Excel formula into a stream of
-// tokens.
-func ExcelParser() Parser {
-	return Parser{}
+// tokens with the optional parser options.
+func ExcelParser(opts ...Options) Parser {
+	ps := Parser{}
+	if len(opts) > 0 {
+		ps.options = opts[len(opts)-1]
+	}
+	if len(ps.options.DefinedNames) > 0 {
+		ps.names = make(map[string]bool, len(ps.options.DefinedNames))
+		for _, name := range ps.options.DefinedNames {
+			ps.names[strings.ToUpper(name)] = true
+		}
+	}
+	return ps
+}
+
+// Tokenize provides function to parse formula as a token stream (list) with
+// the optional parser options. It's safe for concurrent use, the tokens are
+// the same as Parse of a new parser.
+func Tokenize(formula string, opts ...Options) []Token {
+	ps := ExcelParser(opts...)
+	return ps.Parse(formula)
+}
+
+// operandSubType provides function to classify an operand which is not a
+// number or logical as a defined name, a range or a structured reference.
+func (ps *Parser) operandSubType(value string) string {
+	if len(ps.names) > 0 {
+		name := value
+		if _, n, ok := splitSheet(value); ok {
+			name = n
+		}
+		if ps.names[strings.ToUpper(name)] {
+			return TokenSubTypeName
+		}
+	}
+	if ps.options.R1C1 {
+		if isR1C1Reference(value) {
+			return TokenSubTypeRange
+		}
+	} else if _, ok := parseReference(value); ok {
+		return TokenSubTypeRange
+	}
+	if strings.IndexByte(value, BracketOpen) != -1 {
+		if _, err := ParseStructuredReference(value); err == nil {
+			return TokenSubTypeStructuredReference
+		}
+	}
+	return TokenSubTypeName
+}
+
+// isNumber returns whether the operand value is a number. The values which
+// can't be a number are rejected without parsing, such as cell references.
+func isNumber(value string) bool {
+	if value == "" || !(value[0] >= '0' && value[0] <= '9' || value[0] == '.') {
+		return strings.EqualFold(value, "inf") || strings.EqualFold(value, "infinity") || strings.EqualFold(value, "nan")
+	}
+	hex := len(value) > 1 && value[0] == '0' && value[1]|0x20 == 'x'
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9', c == '.', c == '+', c == '-', c == '_', c|0x20 == 'e':
+		case hex && (i == 1 || c|0x20 == 'p' || (c|0x20 >= 'a' && c|0x20 <= 'f')):
+		default:
+			return false
+		}
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+// classifyOperand provides function to get the subtype of an operand which
+// is not a text or error value.
+func (ps *Parser) classifyOperand(value string) string {
+	if isNumber(value) {
+		return TokenSubTypeNumber
+	}
+	if value == "TRUE" || value == "FALSE" {
+		return TokenSubTypeLogical
+	}
+	return ps.operandSubType(value)
 }
 
 // getTokens return a token stream (list).
+//
+// The Lexer implements the same state machine in Lexer.scan without
+// allocations, and must produce the identical token stream. Any change to
+// the tokenization rules here must be made in Lexer.scan as well, and the
+// formula covering it added to lexerFormulas in lexer_test.go, which checks
+// both implementations against each other.
 func (ps *Parser) getTokens() Tokens {
-	ps.Formula = strings.TrimSpace(ps.Formula)
-	ps.fRune = []rune(ps.Formula)
-	if len(ps.fRune) > 0 && ps.fRune[0] != '=' {
-		ps.Formula = "=" + ps.Formula
-		ps.fRune = []rune(ps.Formula)
+	formula := strings.TrimLeftFunc(ps.Formula, unicode.IsSpace)
+	pos, byteOffset := utf8.RuneCountInString(ps.Formula[:len(ps.Formula)-len(formula)]), len(ps.Formula)-len(formula)
+	formula = strings.TrimRightFunc(formula, unicode.IsSpace)
+	// map each character to the rune and byte offsets in the original formula,
+	// the prepended "=" is mapped to an empty location, the buffers of the
+	// previous formula are reused
+	ps.fRune, ps.fPos, ps.fByte = ps.fRune[:0], ps.fPos[:0], ps.fByte[:0]
+	if len(formula) > 0 && formula[0] != '=' {
+		ps.fRune = append(ps.fRune, '=')
+		ps.fPos, ps.fByte = append(ps.fPos, pos), append(ps.fByte, byteOffset)
+	}
+	for i := 0; i < len(formula); {
+		r, size := utf8.DecodeRuneInString(formula[i:])
+		ps.fRune = append(ps.fRune, r)
+		ps.fPos, ps.fByte = append(ps.fPos, pos), append(ps.fByte, byteOffset)
+		pos, byteOffset, i = pos+1, byteOffset+size, i+size
 	}
+	ps.fPos, ps.fByte = append(ps.fPos, pos), append(ps.fByte, byteOffset)
 
-	var token []rune
+	var (
+		token  []rune
+		start  int
+		locale = ps.options.Locale.normalize()
+	)
 
 	// state-dependent character evaluation (order is important)
 	for !ps.EOF() {
@@ -297,7 +413,7 @@ func (ps *Parser) getTokens() Tokens {
 					ps.Offset++
 				} else {
 					ps.InString = false
-					ps.Tokens.add(string(token), TokenTypeOperand, TokenSubTypeText)
+					ps.Tokens.add(string(token), TokenTypeOperand, TokenSubTypeText, ps.span(start, ps.Offset+1))
 					token = token[:0]
 				}
 			} else {
@@ -308,14 +424,15 @@ func (ps *Parser) getTokens() Tokens {
 		}
 
 		// single-quoted strings (links)
-		// embeds are double
+		// embeds are double, quotes are kept in the token
 		// end does not mark a token
 		if ps.InPath {
 			if ps.currentChar() == QuoteSingle {
 				if ps.nextChar() == QuoteSingle {
-					token = append(token, QuoteSingle)
+					token = append(token, QuoteSingle, QuoteSingle)
 					ps.Offset++
 				} else {
+					token = append(token, QuoteSingle)
 					ps.InPath = false
 				}
 			} else {
@@ -325,12 +442,23 @@ func (ps *Parser) getTokens() Tokens {
 			continue
 		}
 
-		// bracketed strings (range offset or linked workbook name)
-		// no embeds (changed to "()" by Excel)
+		// bracketed strings (range offset, linked workbook name or structured
+		// reference)
+		// nested brackets are counted, embeds are escaped by single quotes
 		// end does not mark a token
 		if ps.InRange {
-			if ps.currentChar() == BracketClose {
-				ps.InRange = false
+			switch ps.currentChar() {
+			case QuoteSingle:
+				if ps.Offset+1 < len(ps.fRune) {
+					token = append(token, QuoteSingle)
+					ps.Offset++
+				}
+			case BracketOpen:
+				ps.depth++
+			case BracketClose:
+				if ps.depth--; ps.depth == 0 {
+					ps.InRange = false
+				}
 			}
 			token = append(token, ps.currentChar())
 			ps.Offset++
@@ -345,7 +473,7 @@ func (ps *Parser) getTokens() Tokens {
 
 			if isAnError(token) {
 				ps.InError = false
-				ps.Tokens.add(string(token), TokenTypeOperand, TokenSubTypeError)
+				ps.Tokens.add(string(token), TokenTypeOperand, TokenSubTypeError, ps.span(start, ps.Offset))
 				token = token[:0]
 			}
 			continue
@@ -365,10 +493,11 @@ func (ps *Parser) getTokens() Tokens {
 		if ps.currentChar() == QuoteDouble {
 			if len(token) > 0 {
 				// not expected
-				ps.Tokens.add(string(token), TokenTypeUnknown, "")
+				ps.Tokens.add(string(token), TokenTypeUnknown, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
 			ps.InString = true
+			start = ps.Offset
 			ps.Offset++
 			continue
 		}
@@ -376,16 +505,21 @@ func (ps *Parser) getTokens() Tokens {
 		if ps.currentChar() == QuoteSingle {
 			if len(token) > 0 {
 				// not expected
-				ps.Tokens.add(string(token), TokenTypeUnknown, "")
+				ps.Tokens.add(string(token), TokenTypeUnknown, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
 			ps.InPath = true
+			start = ps.Offset
+			token = append(token, QuoteSingle)
 			ps.Offset++
 			continue
 		}
 
 		if ps.currentChar() == BracketOpen {
-			ps.InRange = true
+			ps.InRange, ps.depth = true, 1
+			if len(token) == 0 {
+				start = ps.Offset
+			}
 			token = append(token, ps.currentChar())
 			ps.Offset++
 			continue
@@ -394,10 +528,11 @@ func (ps *Parser) getTokens() Tokens {
 		if ps.currentChar() == ErrorStart {
 			if len(token) > 0 {
 				// not expected
-				ps.Tokens.add(string(token), TokenTypeUnknown, "")
+				ps.Tokens.add(string(token), TokenTypeUnknown, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
 			ps.InError = true
+			start = ps.Offset
 			token = append(token, ps.currentChar())
 			ps.Offset++
 			continue
@@ -407,34 +542,41 @@ func (ps *Parser) getTokens() Tokens {
 		if ps.currentChar() == BraceOpen {
 			if len(token) > 0 {
 				// not expected
-				ps.Tokens.add(string(token), TokenTypeUnknown, "")
+				ps.Tokens.add(string(token), TokenTypeUnknown, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.TokenStack.push(ps.Tokens.add("ARRAY", TokenTypeFunction, TokenSubTypeStart))
-			ps.TokenStack.push(ps.Tokens.add("ARRAYROW", TokenTypeFunction, TokenSubTypeStart))
+			ps.TokenStack.push(ps.Tokens.add("ARRAY", TokenTypeFunction, TokenSubTypeStart, ps.span(ps.Offset, ps.Offset+1)))
+			ps.TokenStack.push(ps.Tokens.add("ARRAYROW", TokenTypeFunction, TokenSubTypeStart, ps.span(ps.Offset+1, ps.Offset+1)))
 			ps.Offset++
 			continue
 		}
 
-		if ps.currentChar() == Semicolon {
+		inArray := ps.TokenStack.value() == "ARRAYROW"
+		if ps.currentChar() == locale.ArrayRowSeparator && (inArray || locale.ArrayRowSeparator != locale.ArgumentSeparator) {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.addRef(ps.TokenStack.pop())
-			ps.Tokens.add(string(Comma), TokenTypeArgument, "")
-			ps.TokenStack.push(ps.Tokens.add("ARRAYROW", TokenTypeFunction, TokenSubTypeStart))
+			if !inArray {
+				ps.diagnose(DiagnosticMisplacedSemicolon, "semicolon outside of an array constant", ps.Offset, ps.Offset+1)
+			}
+			ps.addStop(ps.Offset, ps.Offset)
+			ps.Tokens.add(string(Comma), TokenTypeArgument, "", ps.span(ps.Offset, ps.Offset+1))
+			ps.TokenStack.push(ps.Tokens.add("ARRAYROW", TokenTypeFunction, TokenSubTypeStart, ps.span(ps.Offset+1, ps.Offset+1)))
 			ps.Offset++
 			continue
 		}
 
 		if ps.currentChar() == BraceClose {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.addRef(ps.TokenStack.pop())
-			ps.Tokens.addRef(ps.TokenStack.pop())
+			if ps.TokenStack.value() != "ARRAYROW" {
+				ps.diagnose(DiagnosticUnbalancedBrace, "unexpected closing brace", ps.Offset, ps.Offset+1)
+			}
+			ps.addStop(ps.Offset, ps.Offset)
+			ps.addStop(ps.Offset, ps.Offset+1)
 			ps.Offset++
 			continue
 		}
@@ -442,24 +584,25 @@ func (ps *Parser) getTokens() Tokens {
 		// trim white-space
 		if ps.currentChar() == Whitespace {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.add("", TokenTypeWhitespace, "")
+			start = ps.Offset
 			ps.Offset++
 			for (ps.currentChar() == Whitespace) && (!ps.EOF()) {
 				ps.Offset++
 			}
+			ps.Tokens.add("", TokenTypeWhitespace, "", ps.span(start, ps.Offset))
 			continue
 		}
 
 		// multi-character comparators
 		if isInComparisonSet(ps.doubleChar()) {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.add(string(ps.doubleChar()), TokenTypeOperatorInfix, TokenSubTypeLogical)
+			ps.Tokens.add(string(ps.doubleChar()), TokenTypeOperatorInfix, TokenSubTypeLogical, ps.span(ps.Offset, ps.Offset+2))
 			ps.Offset += 2
 			continue
 		}
@@ -467,10 +610,10 @@ func (ps *Parser) getTokens() Tokens {
 		// standard infix operators
 		if isInfix(ps.currentChar()) {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.add(string(ps.currentChar()), TokenTypeOperatorInfix, "")
+			ps.Tokens.add(string(ps.currentChar()), TokenTypeOperatorInfix, "", ps.span(ps.Offset, ps.Offset+1))
 			ps.Offset++
 			continue
 		}
@@ -478,10 +621,10 @@ func (ps *Parser) getTokens() Tokens {
 		// standard postfix operators
 		if ps.currentChar() == OperatorsPostfix {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.add(string(ps.currentChar()), TokenTypeOperatorPostfix, "")
+			ps.Tokens.add(string(ps.currentChar()), TokenTypeOperatorPostfix, "", ps.span(ps.Offset, ps.Offset+1))
 			ps.Offset++
 			continue
 		}
@@ -489,25 +632,25 @@ func (ps *Parser) getTokens() Tokens {
 		// start subexpression or function
 		if ps.currentChar() == ParenOpen {
 			if len(token) > 0 {
-				ps.TokenStack.push(ps.Tokens.add(string(token), TokenTypeFunction, TokenSubTypeStart))
+				ps.TokenStack.push(ps.Tokens.add(string(token), TokenTypeFunction, TokenSubTypeStart, ps.span(start, ps.Offset+1)))
 				token = token[:0]
 			} else {
-				ps.TokenStack.push(ps.Tokens.add("", TokenTypeSubexpression, TokenSubTypeStart))
+				ps.TokenStack.push(ps.Tokens.add("", TokenTypeSubexpression, TokenSubTypeStart, ps.span(ps.Offset, ps.Offset+1)))
 			}
 			ps.Offset++
 			continue
 		}
 
 		// function, subexpression, array parameters
-		if ps.currentChar() == Comma {
+		if ps.currentChar() == locale.ArgumentSeparator || (inArray && ps.currentChar() == locale.ArrayColumnSeparator) {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
 			if ps.TokenStack.tp() != TokenTypeFunction {
-				ps.Tokens.add(string(ps.currentChar()), TokenTypeOperatorInfix, TokenSubTypeUnion)
+				ps.Tokens.add(string(Comma), TokenTypeOperatorInfix, TokenSubTypeUnion, ps.span(ps.Offset, ps.Offset+1))
 			} else {
-				ps.Tokens.add(string(ps.currentChar()), TokenTypeArgument, "")
+				ps.Tokens.add(string(Comma), TokenTypeArgument, "", ps.span(ps.Offset, ps.Offset+1))
 			}
 			ps.Offset++
 			continue
@@ -516,22 +659,35 @@ func (ps *Parser) getTokens() Tokens {
 		// stop subexpression
 		if ps.currentChar() == ParenClose {
 			if len(token) > 0 {
-				ps.Tokens.add(string(token), TokenTypeOperand, "")
+				ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 				token = token[:0]
 			}
-			ps.Tokens.addRef(ps.TokenStack.pop())
+			if ps.TokenStack.token() == nil || ps.TokenStack.value() == "ARRAYROW" {
+				ps.diagnose(DiagnosticUnbalancedParenthesis, "unexpected closing parenthesis", ps.Offset, ps.Offset+1)
+			}
+			ps.addStop(ps.Offset, ps.Offset+1)
 			ps.Offset++
 			continue
 		}
 
 		// token accumulation
-		token = append(token, ps.currentChar())
+		// localized decimal separators are converted to "."
+		if len(token) == 0 {
+			start = ps.Offset
+		}
+		if ps.currentChar() == locale.DecimalSeparator && isDigits(token) {
+			token = append(token, '.')
+		} else {
+			token = append(token, ps.currentChar())
+		}
 		ps.Offset++
 	}
 
+	ps.checkEOF(start)
+
 	// dump remaining accumulation
 	if len(token) > 0 {
-		ps.Tokens.add(string(token), TokenTypeOperand, "")
+		ps.Tokens.add(string(token), TokenTypeOperand, "", ps.span(start, ps.Offset))
 	}
 
 	// move all tokens to a new collection, excluding all unnecessary white-space tokens
@@ -545,16 +701,12 @@ func (ps *Parser) getTokens() Tokens {
 			} else if !(((ps.Tokens.previous().TType == TokenTypeFunction) && (ps.Tokens.previous().TSubType == TokenSubTypeStop)) || ((ps.Tokens.previous().TType == TokenTypeSubexpression) && (ps.Tokens.previous().TSubType == TokenSubTypeStop)) || (ps.Tokens.previous().TType == TokenTypeOperand)) {
 			} else if !(((ps.Tokens.next().TType == TokenTypeFunction) && (ps.Tokens.next().TSubType == TokenSubTypeStart)) || ((ps.Tokens.next().TType == TokenTypeSubexpression) && (ps.Tokens.next().TSubType == TokenSubTypeStart)) || (ps.Tokens.next().TType == TokenTypeOperand)) {
 			} else {
-				tokens2.add(token.TValue, TokenTypeOperatorInfix, TokenSubTypeIntersection)
+				tokens2.add(token.TValue, TokenTypeOperatorInfix, TokenSubTypeIntersection, token.Span)
 			}
 			continue
 		}
 
-		tokens2.addRef(Token{
-			TValue:   token.TValue,
-			TType:    token.TType,
-			TSubType: token.TSubType,
-		})
+		tokens2.addRef(*token)
 	}
 
 	// switch infix "-" operator to prefix when appropriate, switch infix "+"
@@ -596,15 +748,7 @@ func (ps *Parser) getTokens() Tokens {
 		}
 
 		if (token.TType == TokenTypeOperand) && (len(token.TSubType) == 0) {
-			if _, err := strconv.ParseFloat(string(token.TValue), 64); err != nil {
-				if (string(token.TValue) == "TRUE") || (string(token.TValue) == "FALSE") {
-					token.TSubType = TokenSubTypeLogical
-				} else {
-					token.TSubType = TokenSubTypeRange
-				}
-			} else {
-				token.TSubType = TokenSubTypeNumber
-			}
+			token.TSubType = ps.classifyOperand(token.TValue)
 			continue
 		}
 
@@ -622,14 +766,11 @@ func (ps *Parser) getTokens() Tokens {
 	tokens := fTokens(0, len(tokens2.Items))
 	for tokens2.moveNext() {
 		if tokens2.current().TType != TokenTypeNoop {
-			tokens.addRef(Token{
-				TValue:   tokens2.current().TValue,
-				TType:    tokens2.current().TType,
-				TSubType: tokens2.current().TSubType,
-			})
+			tokens.addRef(*tokens2.current())
 		}
 	}
 
+	ps.checkTokens(tokens.Items)
 	tokens.reset()
 	if len(tokens.Items) == 0 {
 		tokens.Items = nil
@@ -637,6 +778,25 @@ func (ps *Parser) getTokens() Tokens {
 	return tokens
 }
 
+// span provides function to get the location in the original formula of the
+// characters between the given offsets.
+func (ps *Parser) span(start, end int) Span {
+	return Span{
+		Start:     ps.fPos[start],
+		End:       ps.fPos[end],
+		ByteStart: ps.fByte[start],
+		ByteEnd:   ps.fByte[end],
+	}
+}
+
+// addStop provides function to pop a token off the stack and add the
+// corresponding stop token located between the given offsets to the list.
+func (ps *Parser) addStop(start, end int) {
+	token := ps.TokenStack.pop()
+	token.Span = ps.span(start, end)
+	ps.Tokens.addRef(token)
+}
+
 // doubleChar provides function to get two characters after the current
 // position.
 func (ps *Parser) doubleChar() []rune {
@@ -664,10 +824,25 @@ func (ps *Parser) EOF() bool {
 	return ps.Offset >= len(ps.fRune)
 }
 
-// Parse provides function to parse formula as a token stream (list).
+// reset provides function to clear the state left by the previous formula.
+// The zero Tokens index skips the "=" token at the start of the formula.
+func (ps *Parser) reset(formula string) {
+	ps.Formula, ps.Offset, ps.depth = formula, 0, 0
+	ps.Tokens, ps.TokenStack = Tokens{}, Tokens{}
+	ps.InString, ps.InPath, ps.InRange, ps.InError = false, false, false, false
+	ps.diagnostics = nil
+}
+
+// Parse provides function to parse formula as a token stream (list). The
+// state of the parser is reset before parsing, so a parser can be reused
+// for many formulas, or pooled with sync.Pool. A parser is not safe for
+// concurrent use, use Tokenize or a parser per goroutine instead.
 func (ps *Parser) Parse(formula string) []Token {
-	ps.Formula = formula
+	ps.reset(formula)
 	ps.Tokens = ps.getTokens()
+	if ps.options.Lossless {
+		ps.original = append(ps.original[:0], ps.Tokens.Items...)
+	}
 	return ps.Tokens.Items
 }
 
@@ -698,28 +873,82 @@ func (ps *Parser) PrettyPrint() string {
 	return output.String()
 }
 
-// Render provides function to get formatted formula after parsed.
+// Render provides function to get formatted formula after parsed, with the
+// English separators. With the lossless option, the original text of the
+// formula is kept for the unchanged tokens.
 func (ps *Parser) Render() string {
+	if ps.options.Lossless {
+		return ps.renderLossless()
+	}
+	return renderTokens(ps.Tokens.Items, LocaleEnglish)
+}
+
+// renderer directly maps the state of rendering a token stream with the
+// separators of the locale. Stack is the values of the open functions,
+// rowStop reports whether the previous token closes an array row.
+type renderer struct {
+	locale  Locale
+	stack   []string
+	rowStop bool
+}
+
+// token provides function to get the formula text of the next token.
+func (r *renderer) token(t Token) string {
+	afterRow := r.rowStop
+	r.rowStop = false
+	switch {
+	case t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStart:
+		r.stack = append(r.stack, t.TValue)
+		if t.TValue == "ARRAY" {
+			return string(BraceOpen)
+		}
+		if t.TValue == "ARRAYROW" {
+			return ""
+		}
+		return t.TValue + string(ParenOpen)
+	case t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStop:
+		value := ""
+		if len(r.stack) > 0 {
+			value, r.stack = r.stack[len(r.stack)-1], r.stack[:len(r.stack)-1]
+		}
+		if r.rowStop = value == "ARRAYROW"; r.rowStop {
+			return ""
+		}
+		if value == "ARRAY" {
+			return string(BraceClose)
+		}
+		return string(ParenClose)
+	case t.TType == TokenTypeSubexpression && t.TSubType == TokenSubTypeStart:
+		return string(ParenOpen)
+	case t.TType == TokenTypeSubexpression && t.TSubType == TokenSubTypeStop:
+		return string(ParenClose)
+	case t.TType == TokenTypeArgument:
+		if afterRow {
+			return string(r.locale.ArrayRowSeparator)
+		}
+		if len(r.stack) > 0 && r.stack[len(r.stack)-1] == "ARRAYROW" {
+			return string(r.locale.ArrayColumnSeparator)
+		}
+		return string(r.locale.ArgumentSeparator)
+	case t.TType == TokenTypeOperatorInfix && t.TSubType == TokenSubTypeUnion:
+		return string(r.locale.ArgumentSeparator)
+	case t.TType == TokenTypeOperand && t.TSubType == TokenSubTypeNumber && r.locale.DecimalSeparator != '.':
+		return strings.Replace(t.TValue, ".", string(r.locale.DecimalSeparator), 1)
+	case t.TType == TokenTypeOperand && t.TSubType == TokenSubTypeText:
+		return string(QuoteDouble) + strings.Replace(t.TValue, `"`, `""`, -1) + string(QuoteDouble)
+	case t.TType == TokenTypeOperatorInfix && t.TSubType == TokenSubTypeIntersection:
+		return string(Whitespace)
+	}
+	return t.TValue
+}
+
+// renderTokens provides function to get the formula text of a token stream
+// with the separators of the given locale.
+func renderTokens(tokens []Token, locale Locale) string {
 	var output strings.Builder
-	for _, t := range ps.Tokens.Items {
-		if t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStart {
-			output.WriteString(t.TValue)
-			output.WriteRune(ParenOpen)
-		} else if t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStop {
-			output.WriteRune(ParenClose)
-		} else if t.TType == TokenTypeSubexpression && t.TSubType == TokenSubTypeStart {
-			output.WriteRune(ParenOpen)
-		} else if t.TType == TokenTypeSubexpression && t.TSubType == TokenSubTypeStop {
-			output.WriteRune(ParenClose)
-		} else if t.TType == TokenTypeOperand && t.TSubType == TokenSubTypeText {
-			output.WriteRune(QuoteDouble)
-			output.WriteString(t.TValue)
-			output.WriteRune(QuoteDouble)
-		} else if t.TType == TokenTypeOperatorInfix && t.TSubType == TokenSubTypeIntersection {
-			output.WriteRune(Whitespace)
-		} else {
-			output.WriteString(t.TValue)
-		}
+	r := renderer{locale: locale}
+	for _, t := range tokens {
+		output.WriteString(r.token(t))
 	}
 	return output.String()
 }
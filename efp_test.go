@@ -1,6 +1,11 @@
 package efp
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
 
 func TestEFP(t *testing.T) {
 	formulae := []string{
@@ -87,3 +92,113 @@ func TestNonFormulas(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenSpan(t *testing.T) {
+	formula := "  SUM(\"あ\"\"b\", A1 B1)+{1;2}  "
+	p := ExcelParser()
+	tokens := p.Parse(formula)
+	expected := []struct {
+		source     string
+		start, end int
+	}{
+		{`SUM(`, 2, 6}, {`"あ""b"`, 6, 12}, {`,`, 12, 13}, {`A1`, 14, 16}, {` `, 16, 17},
+		{`B1`, 17, 19}, {`)`, 19, 20}, {`+`, 20, 21}, {`{`, 21, 22}, {``, 22, 22}, {`1`, 22, 23},
+		{``, 23, 23}, {`;`, 23, 24}, {``, 24, 24}, {`2`, 24, 25}, {``, 25, 25}, {`}`, 25, 26},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+	for i, token := range tokens {
+		if source := formula[token.Span.ByteStart:token.Span.ByteEnd]; source != expected[i].source {
+			t.Errorf("token %d: expected source %q, got %q", i, expected[i].source, source)
+		}
+		if token.Span.Start != expected[i].start || token.Span.End != expected[i].end {
+			t.Errorf("token %d: expected rune span [%d, %d), got [%d, %d)", i,
+				expected[i].start, expected[i].end, token.Span.Start, token.Span.End)
+		}
+	}
+	if p.Formula != formula {
+		t.Errorf("expected formula %q, got %q", formula, p.Formula)
+	}
+}
+
+func TestOperandSubType(t *testing.T) {
+	for _, c := range []struct {
+		formula  string
+		options  []Options
+		expected []string
+	}{
+		{`=A1+AName*TaxRate`, nil, []string{TokenSubTypeRange, TokenSubTypeName, TokenSubTypeName}},
+		{`=Sheet1!$A$1:$B$2&Sheet1!Total`, nil, []string{TokenSubTypeRange, TokenSubTypeName}},
+		{`=SUM(A:A,1:1,XFE1)`, nil, []string{TokenSubTypeRange, TokenSubTypeRange, TokenSubTypeName}},
+		{`=LOG10+Sheet1!log10`, []Options{{DefinedNames: []string{"LOG10"}}}, []string{TokenSubTypeName, TokenSubTypeName}},
+		{`=TRUE+1`, []Options{{DefinedNames: []string{"TaxRate"}}}, []string{TokenSubTypeLogical, TokenSubTypeNumber}},
+		{`=SUM(R1C1:R[-1]C[2],RC,Sheet1!C3)+Rate`, []Options{{R1C1: true}},
+			[]string{TokenSubTypeRange, TokenSubTypeRange, TokenSubTypeRange, TokenSubTypeName}},
+		{`=R1C1+A1`, nil, []string{TokenSubTypeName, TokenSubTypeRange}},
+	} {
+		p := ExcelParser(c.options...)
+		var subTypes []string
+		for _, token := range p.Parse(c.formula) {
+			if token.TType == TokenTypeOperand {
+				subTypes = append(subTypes, token.TSubType)
+			}
+		}
+		if strings.Join(subTypes, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("%s: expected %v, got %v", c.formula, c.expected, subTypes)
+		}
+	}
+}
+
+func TestParserReuse(t *testing.T) {
+	formulae := []string{`=SUM(A1:B2`, `="abc`, `='Sheet 1`, `=Table1[Col`, `=#BAD`, `={1;2`, `=1+2`, `=SUM(A1,B1)`}
+	p := ExcelParser(Options{Lossless: true})
+	for _, f := range formulae {
+		tokens, diagnostics := p.ParseWithErrors(f)
+		fresh := ExcelParser(Options{Lossless: true})
+		expected, expectedDiagnostics := fresh.ParseWithErrors(f)
+		if !reflect.DeepEqual(tokens, expected) || !reflect.DeepEqual(diagnostics, expectedDiagnostics) {
+			t.Errorf("%s: expected %+v %v, got %+v %v", f, expected, expectedDiagnostics, tokens, diagnostics)
+		}
+		if rendered := p.Render(); rendered != f {
+			t.Errorf("expected %q, got %q", f, rendered)
+		}
+		if !reflect.DeepEqual(Tokenize(f, Options{Lossless: true}), expected) {
+			t.Errorf("%s: unexpected tokens of Tokenize", f)
+		}
+	}
+	if tokens := Tokenize(""); tokens != nil {
+		t.Errorf("expected nil tokens, got %+v", tokens)
+	}
+}
+
+func TestConcurrentParse(t *testing.T) {
+	formulae := []string{`=SUM(A1:B2,C3)*2`, `=IF(A1>0,"yes","no")`, `={1,2;3,4}`, `=Sheet1!A1 Sheet1!B1`, `=-1%+Table1[Qty]`}
+	expected := make([][]Token, len(formulae))
+	for i, f := range formulae {
+		expected[i] = Tokenize(f)
+	}
+	pool := sync.Pool{New: func() interface{} {
+		p := ExcelParser()
+		return &p
+	}}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				n := (g + i) % len(formulae)
+				p := pool.Get().(*Parser)
+				if tokens := p.Parse(formulae[n]); !reflect.DeepEqual(tokens, expected[n]) {
+					t.Errorf("%s: expected %+v, got %+v", formulae[n], expected[n], tokens)
+				}
+				pool.Put(p)
+				if tokens := Tokenize(formulae[n]); !reflect.DeepEqual(tokens, expected[n]) {
+					t.Errorf("%s: expected %+v, got %+v", formulae[n], expected[n], tokens)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,810 @@
+package efp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Formula error values.
+const (
+	FormulaErrorNULL        = "#NULL!"
+	FormulaErrorDIV         = "#DIV/0!"
+	FormulaErrorVALUE       = "#VALUE!"
+	FormulaErrorREF         = "#REF!"
+	FormulaErrorNAME        = "#NAME?"
+	FormulaErrorNUM         = "#NUM!"
+	FormulaErrorNA          = "#N/A"
+	FormulaErrorSPILL       = "#SPILL!"
+	FormulaErrorCALC        = "#CALC!"
+	FormulaErrorGETTINGDATA = "#GETTING_DATA"
+)
+
+// Worksheet limits.
+const (
+	MaxColumns = 16384
+	TotalRows  = 1048576
+)
+
+// maxNameDepth is the maximum nesting depth of defined names referring to
+// other defined names.
+const maxNameDepth = 32
+
+// ValueType is the type of a formula value.
+type ValueType byte
+
+// Formula value types.
+const (
+	ValueTypeEmpty ValueType = iota
+	ValueTypeNumber
+	ValueTypeText
+	ValueTypeLogical
+	ValueTypeError
+	ValueTypeArray
+	ValueTypeReference
+)
+
+// Value directly maps a formula value. Text holds the text of a text value or
+// the error value of an error, Array holds the rows of an array value.
+type Value struct {
+	Type   ValueType
+	Number float64
+	Text   string
+	Bool   bool
+	Array  [][]Value
+	areas  []Reference
+	isRef  bool
+}
+
+// NumberValue returns a number value.
+func NumberValue(number float64) Value {
+	return Value{Type: ValueTypeNumber, Number: number}
+}
+
+// TextValue returns a text value.
+func TextValue(text string) Value {
+	return Value{Type: ValueTypeText, Text: text}
+}
+
+// BoolValue returns a logical value.
+func BoolValue(b bool) Value {
+	return Value{Type: ValueTypeLogical, Bool: b}
+}
+
+// ErrorValue returns an error value, such as FormulaErrorDIV.
+func ErrorValue(err string) Value {
+	return Value{Type: ValueTypeError, Text: err}
+}
+
+// ArrayValue returns an array value with the given rows.
+func ArrayValue(rows [][]Value) Value {
+	return Value{Type: ValueTypeArray, Array: rows}
+}
+
+// String returns the text representation of the value.
+func (v Value) String() string {
+	switch v.Type {
+	case ValueTypeNumber:
+		return formatNumber(v.Number)
+	case ValueTypeText, ValueTypeError:
+		return v.Text
+	case ValueTypeLogical:
+		if v.Bool {
+			return "TRUE"
+		}
+		return "FALSE"
+	case ValueTypeArray:
+		var output strings.Builder
+		output.WriteRune(BraceOpen)
+		for i, row := range v.Array {
+			if i > 0 {
+				output.WriteRune(Semicolon)
+			}
+			for j, item := range row {
+				if j > 0 {
+					output.WriteRune(Comma)
+				}
+				if item.Type == ValueTypeText {
+					output.WriteRune(QuoteDouble)
+					output.WriteString(strings.Replace(item.Text, `"`, `""`, -1))
+					output.WriteRune(QuoteDouble)
+					continue
+				}
+				output.WriteString(item.String())
+			}
+		}
+		output.WriteRune(BraceClose)
+		return output.String()
+	}
+	return ""
+}
+
+// formatNumber returns the text of the number with at most 15 significant
+// digits, as Excel does in the general number format. The scientific
+// notation is used when the integer part has more than 15 digits.
+func formatNumber(number float64) string {
+	number, _ = strconv.ParseFloat(strconv.FormatFloat(number, 'g', 15, 64), 64)
+	if abs := math.Abs(number); abs != 0 && (abs >= 1e15 || abs < 1e-9) {
+		return strconv.FormatFloat(number, 'E', -1, 64)
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// ToNumber returns the value coerced to a number, or a #VALUE! error if the
+// value can't be represented as a number.
+func (v Value) ToNumber() Value {
+	switch v.Type {
+	case ValueTypeNumber, ValueTypeError:
+		return v
+	case ValueTypeEmpty:
+		return NumberValue(0)
+	case ValueTypeLogical:
+		if v.Bool {
+			return NumberValue(1)
+		}
+		return NumberValue(0)
+	case ValueTypeText:
+		text := strings.TrimSpace(v.Text)
+		percent := strings.HasSuffix(text, "%")
+		if percent {
+			text = strings.TrimSpace(strings.TrimSuffix(text, "%"))
+		}
+		if !isDecimal(text) {
+			return ErrorValue(FormulaErrorVALUE)
+		}
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return ErrorValue(FormulaErrorVALUE)
+		}
+		if percent {
+			number /= 100
+		}
+		return NumberValue(number)
+	}
+	return ErrorValue(FormulaErrorVALUE)
+}
+
+// isDecimal returns whether the text is a decimal number with an optional
+// sign and exponent, such as -1.5E+3. Unlike strconv.ParseFloat, infinities,
+// NaN, hexadecimal numbers and digit separators are not accepted.
+func isDecimal(text string) bool {
+	i, digits := 0, 0
+	if i < len(text) && (text[i] == '+' || text[i] == '-') {
+		i++
+	}
+	for ; i < len(text) && text[i] >= '0' && text[i] <= '9'; i++ {
+		digits++
+	}
+	if i < len(text) && text[i] == '.' {
+		for i++; i < len(text) && text[i] >= '0' && text[i] <= '9'; i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if i < len(text) && (text[i] == 'e' || text[i] == 'E') {
+		i++
+		if i < len(text) && (text[i] == '+' || text[i] == '-') {
+			i++
+		}
+		if i == len(text) {
+			return false
+		}
+		for ; i < len(text) && text[i] >= '0' && text[i] <= '9'; i++ {
+		}
+	}
+	return i == len(text)
+}
+
+// ToText returns the value coerced to a text.
+func (v Value) ToText() Value {
+	switch v.Type {
+	case ValueTypeText, ValueTypeError:
+		return v
+	case ValueTypeArray, ValueTypeReference:
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	return TextValue(v.String())
+}
+
+// ToBool returns the value coerced to a logical, or a #VALUE! error if the
+// value can't be represented as a logical.
+func (v Value) ToBool() Value {
+	switch v.Type {
+	case ValueTypeLogical, ValueTypeError:
+		return v
+	case ValueTypeEmpty:
+		return BoolValue(false)
+	case ValueTypeNumber:
+		return BoolValue(v.Number != 0)
+	case ValueTypeText:
+		switch strings.ToUpper(v.Text) {
+		case "TRUE":
+			return BoolValue(true)
+		case "FALSE":
+			return BoolValue(false)
+		}
+	}
+	return ErrorValue(FormulaErrorVALUE)
+}
+
+// Resolver is the interface that provides cell values and defined names to
+// the evaluator. An empty sheet name refers to the sheet of the evaluated
+// formula.
+//
+// CellValue returns the value of a single cell, such as A1. RangeValues
+// returns the values of a range, such as A1:B2, row by row, it may return
+// fewer rows and columns than the range when the trailing cells are empty,
+// the evaluator pads them with empty values to the size of the range.
+// DefinedName returns the formula text a defined name refers to, such as
+// "Sheet1!$A$1:$B$2", or an empty string if the name is not defined.
+type Resolver interface {
+	CellValue(sheet, cell string) (Value, error)
+	RangeValues(sheet, ref string) ([][]Value, error)
+	DefinedName(sheet, name string) (string, error)
+}
+
+// Evaluator computes the value of a formula. Sheet is the name of the
+// worksheet containing the formula, Functions holds the worksheet functions
+// available to this evaluator only, keyed by upper case name, which take
+// precedence over the functions registered by RegisterFunction.
+type Evaluator struct {
+	Resolver  Resolver
+	Sheet     string
+	Functions map[string]Function
+	depth     int
+}
+
+// NewEvaluator returns an evaluator of formulas on the given worksheet.
+func NewEvaluator(sheet string, resolver Resolver) *Evaluator {
+	return &Evaluator{Resolver: resolver, Sheet: sheet}
+}
+
+// EvaluateFormula provides function to parse and compute the value of the
+// formula.
+func (e *Evaluator) EvaluateFormula(formula string) (Value, error) {
+	ps := ExcelParser()
+	node, err := ps.ParseTree(formula)
+	if err != nil {
+		return Value{}, err
+	}
+	return e.Evaluate(node)
+}
+
+// Evaluate provides function to compute the value of the parsed formula. Excel
+// errors, such as #DIV/0!, are returned as error values, the returned error is
+// reserved for failures of the resolver.
+func (e *Evaluator) Evaluate(node Node) (Value, error) {
+	v, err := e.eval(node)
+	if err != nil {
+		return Value{}, err
+	}
+	return e.deref(v)
+}
+
+// eval returns the value of the node, references are left unresolved.
+func (e *Evaluator) eval(node Node) (Value, error) {
+	switch n := node.(type) {
+	case nil:
+		return Value{}, nil
+	case *Operand:
+		return e.evalOperand(n.Token)
+	case *Subexpression:
+		return e.eval(n.Expression)
+	case *UnaryOp:
+		v, err := e.evalDeref(n.Operand)
+		if err != nil {
+			return v, err
+		}
+		if n.Operator.TValue != "-" {
+			return ErrorValue(FormulaErrorVALUE), nil
+		}
+		return lift1(v, func(x float64) Value { return NumberValue(-x) }), nil
+	case *PostfixOp:
+		v, err := e.evalDeref(n.Operand)
+		if err != nil {
+			return v, err
+		}
+		return lift1(v, func(x float64) Value { return NumberValue(x / 100) }), nil
+	case *BinaryOp:
+		return e.evalBinary(n)
+	case *FunctionCall:
+		return e.evalFunction(n)
+	case *ArrayLiteral:
+		rows := make([][]Value, len(n.Rows))
+		for i, row := range n.Rows {
+			rows[i] = make([]Value, len(row))
+			for j, item := range row {
+				v, err := e.evalDeref(item)
+				if err != nil {
+					return v, err
+				}
+				rows[i][j] = v
+			}
+		}
+		return ArrayValue(rows), nil
+	}
+	return Value{}, fmt.Errorf("unsupported node %T", node)
+}
+
+// evalDeref returns the value of the node with references resolved.
+func (e *Evaluator) evalDeref(node Node) (Value, error) {
+	v, err := e.eval(node)
+	if err != nil {
+		return v, err
+	}
+	return e.deref(v)
+}
+
+// evalOperand returns the value of an operand token.
+func (e *Evaluator) evalOperand(t Token) (Value, error) {
+	switch t.TSubType {
+	case TokenSubTypeNumber:
+		if !isDecimal(t.TValue) {
+			return e.evalName(t.TValue)
+		}
+		number, err := strconv.ParseFloat(t.TValue, 64)
+		if err != nil {
+			return ErrorValue(FormulaErrorNUM), nil
+		}
+		return NumberValue(number), nil
+	case TokenSubTypeText:
+		return TextValue(t.TValue), nil
+	case TokenSubTypeLogical:
+		return BoolValue(strings.ToUpper(t.TValue) == "TRUE"), nil
+	case TokenSubTypeError:
+		if i := strings.LastIndex(t.TValue, "!#"); i != -1 {
+			return ErrorValue(t.TValue[i+1:]), nil
+		}
+		return ErrorValue(t.TValue), nil
+	case TokenSubTypeName:
+		return e.evalName(t.TValue)
+	}
+	if ref, err := ParseReference(t.TValue); err == nil {
+		if ref.Sheet == "" {
+			ref.Sheet = e.Sheet
+		}
+		return Value{Type: ValueTypeReference, areas: []Reference{ref}}, nil
+	}
+	return e.evalName(t.TValue)
+}
+
+// evalName returns the value of the formula a defined name refers to.
+func (e *Evaluator) evalName(name string) (Value, error) {
+	if e.Resolver == nil {
+		return ErrorValue(FormulaErrorNAME), nil
+	}
+	sheet := e.Sheet
+	if i := strings.LastIndexByte(name, '!'); i != -1 {
+		sheet, name = strings.Trim(name[:i], "'"), name[i+1:]
+	}
+	formula, err := e.Resolver.DefinedName(sheet, name)
+	if err != nil || formula == "" {
+		return ErrorValue(FormulaErrorNAME), err
+	}
+	if e.depth >= maxNameDepth {
+		return Value{}, fmt.Errorf("defined name %s is nested too deeply", name)
+	}
+	ps := ExcelParser()
+	node, err := ps.ParseTree(formula)
+	if err != nil {
+		return Value{}, err
+	}
+	e.depth++
+	defer func() { e.depth-- }()
+	return e.eval(node)
+}
+
+// deref returns the value with references resolved to cell values. The areas
+// of a multiple areas reference are flattened into a single column array.
+func (e *Evaluator) deref(v Value) (Value, error) {
+	if v.Type != ValueTypeReference {
+		return v, nil
+	}
+	v, err := e.resolve(v)
+	v.isRef = true
+	return v, err
+}
+
+// resolve returns the cell values of a reference.
+func (e *Evaluator) resolve(v Value) (Value, error) {
+	if e.Resolver == nil {
+		return ErrorValue(FormulaErrorREF), nil
+	}
+	var flattened [][]Value
+	for _, area := range v.areas {
+		if area.Workbook != "" || area.LastSheet != "" {
+			return ErrorValue(FormulaErrorREF), nil
+		}
+		sheet := area.Sheet
+		if sheet == e.Sheet {
+			sheet = ""
+		}
+		col1, row1, col2, row2 := area.Bounds()
+		from, _ := CoordinatesToCellName(col1, row1)
+		if !area.Range {
+			cell, err := e.Resolver.CellValue(sheet, from)
+			if err != nil || len(v.areas) == 1 {
+				return cell, err
+			}
+			flattened = append(flattened, []Value{cell})
+			continue
+		}
+		to, _ := CoordinatesToCellName(col2, row2)
+		rows, err := e.Resolver.RangeValues(sheet, from+":"+to)
+		if err != nil {
+			return Value{}, err
+		}
+		rows = padRows(rows, row2-row1+1, col2-col1+1)
+		if len(v.areas) == 1 {
+			return ArrayValue(rows), nil
+		}
+		for _, row := range rows {
+			for _, cell := range row {
+				flattened = append(flattened, []Value{cell})
+			}
+		}
+	}
+	return ArrayValue(flattened), nil
+}
+
+// padRows returns the values of a range padded with empty values to the
+// given number of rows and columns, the rows without any value share the
+// same empty row. The values are returned as is if they aren't trimmed.
+func padRows(rows [][]Value, height, width int) [][]Value {
+	trimmed := len(rows) < height
+	for _, row := range rows {
+		trimmed = trimmed || len(row) < width
+	}
+	if !trimmed {
+		return rows
+	}
+	var (
+		padded = make([][]Value, height)
+		empty  []Value
+	)
+	for i := range padded {
+		switch {
+		case i < len(rows) && len(rows[i]) >= width:
+			padded[i] = rows[i]
+		case i < len(rows) && len(rows[i]) > 0:
+			padded[i] = make([]Value, width)
+			copy(padded[i], rows[i])
+		default:
+			if empty == nil {
+				empty = make([]Value, width)
+			}
+			padded[i] = empty
+		}
+	}
+	return padded
+}
+
+// evalBinary returns the value of an infix operation.
+func (e *Evaluator) evalBinary(n *BinaryOp) (Value, error) {
+	if n.Operator.TSubType == TokenSubTypeIntersection || n.Operator.TSubType == TokenSubTypeUnion {
+		left, err := e.eval(n.Left)
+		if err != nil {
+			return left, err
+		}
+		right, err := e.eval(n.Right)
+		if err != nil {
+			return right, err
+		}
+		if left.Type == ValueTypeError {
+			return left, nil
+		}
+		if right.Type == ValueTypeError {
+			return right, nil
+		}
+		if left.Type != ValueTypeReference || right.Type != ValueTypeReference {
+			return ErrorValue(FormulaErrorVALUE), nil
+		}
+		if n.Operator.TSubType == TokenSubTypeUnion {
+			areas := make([]Reference, 0, len(left.areas)+len(right.areas))
+			return Value{Type: ValueTypeReference, areas: append(append(areas, left.areas...), right.areas...)}, nil
+		}
+		return intersect(left, right), nil
+	}
+	left, err := e.evalDeref(n.Left)
+	if err != nil {
+		return left, err
+	}
+	right, err := e.evalDeref(n.Right)
+	if err != nil {
+		return right, err
+	}
+	op := n.Operator.TValue
+	if n.Operator.TSubType == TokenSubTypeConcatenation {
+		return lift2(left, right, func(x, y Value) Value {
+			if x = x.ToText(); x.Type == ValueTypeError {
+				return x
+			}
+			if y = y.ToText(); y.Type == ValueTypeError {
+				return y
+			}
+			return TextValue(x.Text + y.Text)
+		}), nil
+	}
+	if n.Operator.TSubType == TokenSubTypeLogical {
+		return lift2(left, right, func(x, y Value) Value {
+			if x.Type == ValueTypeError {
+				return x
+			}
+			if y.Type == ValueTypeError {
+				return y
+			}
+			return BoolValue(compareResult(op, compareValues(x, y)))
+		}), nil
+	}
+	return lift2(left, right, func(x, y Value) Value {
+		if x = x.ToNumber(); x.Type == ValueTypeError {
+			return x
+		}
+		if y = y.ToNumber(); y.Type == ValueTypeError {
+			return y
+		}
+		return arithmetic(op, x.Number, y.Number)
+	}), nil
+}
+
+// arithmetic returns the result of a math operator applied to two numbers.
+func arithmetic(op string, x, y float64) Value {
+	var result float64
+	switch op {
+	case "+":
+		result = x + y
+	case "-":
+		result = x - y
+	case "*":
+		result = x * y
+	case "/":
+		if y == 0 {
+			return ErrorValue(FormulaErrorDIV)
+		}
+		result = x / y
+	case "^":
+		if x == 0 && y <= 0 {
+			if y == 0 {
+				return ErrorValue(FormulaErrorNUM)
+			}
+			return ErrorValue(FormulaErrorDIV)
+		}
+		result = math.Pow(x, y)
+	default:
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return ErrorValue(FormulaErrorNUM)
+	}
+	return NumberValue(result)
+}
+
+// compareResult returns the result of a comparison operator for the given
+// result of compareValues.
+func compareResult(op string, cmp int) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "<>":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// compareValues compares two scalar values in the way Excel does: numbers are
+// less than texts, which are less than logicals, texts are compared case
+// insensitively and empty values are equal to zero, empty text or FALSE.
+func compareValues(x, y Value) int {
+	if x.Type == ValueTypeEmpty {
+		x = emptyLike(y)
+	}
+	if y.Type == ValueTypeEmpty {
+		y = emptyLike(x)
+	}
+	if rx, ry := typeRank(x), typeRank(y); rx != ry {
+		if rx < ry {
+			return -1
+		}
+		return 1
+	}
+	switch x.Type {
+	case ValueTypeNumber:
+		if x.Number < y.Number {
+			return -1
+		} else if x.Number > y.Number {
+			return 1
+		}
+	case ValueTypeText:
+		return strings.Compare(strings.ToUpper(x.Text), strings.ToUpper(y.Text))
+	case ValueTypeLogical:
+		if x.Bool != y.Bool {
+			if y.Bool {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// emptyLike returns the zero value of the type of the given value.
+func emptyLike(v Value) Value {
+	switch v.Type {
+	case ValueTypeText:
+		return TextValue("")
+	case ValueTypeLogical:
+		return BoolValue(false)
+	}
+	return NumberValue(0)
+}
+
+// typeRank returns the sort order of the value type in comparisons.
+func typeRank(v Value) int {
+	switch v.Type {
+	case ValueTypeText:
+		return 1
+	case ValueTypeLogical:
+		return 2
+	case ValueTypeError:
+		return 3
+	}
+	return 0
+}
+
+// lift1 applies a numeric function to a scalar or each item of an array.
+func lift1(v Value, fn func(float64) Value) Value {
+	if v.Type == ValueTypeArray {
+		rows := make([][]Value, len(v.Array))
+		for i, row := range v.Array {
+			rows[i] = make([]Value, len(row))
+			for j, item := range row {
+				rows[i][j] = lift1(item, fn)
+			}
+		}
+		return ArrayValue(rows)
+	}
+	if v = v.ToNumber(); v.Type == ValueTypeError {
+		return v
+	}
+	if v = fn(v.Number); v.Type == ValueTypeNumber && (math.IsNaN(v.Number) || math.IsInf(v.Number, 0)) {
+		return ErrorValue(FormulaErrorNUM)
+	}
+	return v
+}
+
+// lift2 applies a function to two scalars, or to each pair of items when any
+// of the values is an array. Single row, single column and scalar values are
+// broadcast, the items missing from a smaller array are #N/A errors.
+func lift2(x, y Value, fn func(x, y Value) Value) Value {
+	if x.Type != ValueTypeArray && y.Type != ValueTypeArray {
+		return fn(x, y)
+	}
+	xRows, xCols := arraySize(x)
+	yRows, yCols := arraySize(y)
+	rows, cols := xRows, xCols
+	if yRows > rows {
+		rows = yRows
+	}
+	if yCols > cols {
+		cols = yCols
+	}
+	result := make([][]Value, rows)
+	for i := range result {
+		result[i] = make([]Value, cols)
+		for j := range result[i] {
+			result[i][j] = fn(arrayItem(x, i, j), arrayItem(y, i, j))
+		}
+	}
+	return ArrayValue(result)
+}
+
+// arraySize returns the number of rows and columns of the value, scalar
+// values are single item arrays.
+func arraySize(v Value) (int, int) {
+	if v.Type != ValueTypeArray {
+		return 1, 1
+	}
+	cols := 0
+	for _, row := range v.Array {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	return len(v.Array), cols
+}
+
+// arrayItem returns the item of the value at the given position, broadcasting
+// single row, single column and scalar values.
+func arrayItem(v Value, row, col int) Value {
+	if v.Type != ValueTypeArray {
+		return v
+	}
+	rows, cols := arraySize(v)
+	if rows == 1 {
+		row = 0
+	}
+	if cols == 1 {
+		col = 0
+	}
+	if row >= rows || col >= cols {
+		return ErrorValue(FormulaErrorNA)
+	}
+	if col >= len(v.Array[row]) {
+		return Value{}
+	}
+	return v.Array[row][col]
+}
+
+// intersect returns the reference to the cells shared by two references, or
+// a #NULL! error if they have no cells in common.
+func intersect(x, y Value) Value {
+	var areas []Reference
+	for _, a := range x.areas {
+		for _, b := range y.areas {
+			if a.Sheet != b.Sheet || a.Workbook != b.Workbook || a.LastSheet != "" || b.LastSheet != "" {
+				continue
+			}
+			aCol1, aRow1, aCol2, aRow2 := a.Bounds()
+			bCol1, bRow1, bCol2, bRow2 := b.Bounds()
+			area := Reference{Workbook: a.Workbook, Sheet: a.Sheet,
+				Start: CellRef{Col: maxInt(aCol1, bCol1), Row: maxInt(aRow1, bRow1)},
+				End:   CellRef{Col: minInt(aCol2, bCol2), Row: minInt(aRow2, bRow2)}}
+			if area.Start.Col > area.End.Col || area.Start.Row > area.End.Row {
+				continue
+			}
+			area.Range = area.Start != area.End
+			areas = append(areas, area)
+		}
+	}
+	if len(areas) == 0 {
+		return ErrorValue(FormulaErrorNULL)
+	}
+	return Value{Type: ValueTypeReference, areas: areas}
+}
+
+// evalFunction returns the value of a function call. The evaluator and
+// registered functions take precedence over the built-in functions which
+// evaluate their arguments lazily.
+func (e *Evaluator) evalFunction(n *FunctionCall) (Value, error) {
+	fn := e.lookupFunction(n.Name)
+	if fn == nil {
+		if lazy := e.lazyFunction(n.Name); lazy != nil {
+			return lazy(n.Arguments)
+		}
+		return ErrorValue(FormulaErrorNAME), nil
+	}
+	args := make([]Value, len(n.Arguments))
+	for i, arg := range n.Arguments {
+		v, err := e.evalDeref(arg)
+		if err != nil {
+			return v, err
+		}
+		args[i] = v
+	}
+	return fn(args), nil
+}
+
+// minInt returns the smaller of two integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of two integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
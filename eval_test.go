@@ -0,0 +1,178 @@
+package efp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mapResolver provides cell values and defined names from maps for tests.
+type mapResolver struct {
+	cells map[string]Value
+	names map[string]string
+}
+
+func (r *mapResolver) CellValue(sheet, cell string) (Value, error) {
+	if sheet == "Error" {
+		return Value{}, errors.New("resolver error")
+	}
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	return r.cells[sheet+"!"+cell], nil
+}
+
+func (r *mapResolver) RangeValues(sheet, ref string) ([][]Value, error) {
+	area, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	col1, row1, col2, row2 := area.Bounds()
+	if row2 == TotalRows {
+		row2 = 10
+	}
+	var rows [][]Value
+	for row := row1; row <= row2; row++ {
+		var cells []Value
+		for col := col1; col <= col2; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			v, err := r.CellValue(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			cells = append(cells, v)
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+func (r *mapResolver) DefinedName(sheet, name string) (string, error) {
+	return r.names[strings.ToUpper(name)], nil
+}
+
+func newTestResolver() *mapResolver {
+	return &mapResolver{
+		cells: map[string]Value{
+			"Sheet1!A1": NumberValue(1),
+			"Sheet1!A2": NumberValue(2),
+			"Sheet1!B1": NumberValue(3),
+			"Sheet1!B2": TextValue("4"),
+			"Sheet1!C1": TextValue("text"),
+			"Sheet2!A1": NumberValue(10),
+			"Sheet2!A2": ErrorValue(FormulaErrorNA),
+		},
+		names: map[string]string{
+			"TAXRATE": "0.5",
+			"DATA":    "Sheet1!$A$1:$B$2",
+			"LOOP":    "LOOP",
+		},
+	}
+}
+
+// trimmingResolver provides the values of ranges without the trailing empty
+// rows and columns for tests.
+type trimmingResolver struct {
+	*mapResolver
+}
+
+func (r trimmingResolver) RangeValues(sheet, ref string) ([][]Value, error) {
+	rows, err := r.mapResolver.RangeValues(sheet, ref)
+	for i, row := range rows {
+		for len(row) > 0 && row[len(row)-1].Type == ValueTypeEmpty {
+			row = row[:len(row)-1]
+		}
+		rows[i] = row
+	}
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, err
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, c := range []struct{ formula, expected string }{
+		{`=1+2*3`, `7`},
+		{`=-2^2`, `4`},
+		{`=2^-1`, `0.5`},
+		{`=50%*2`, `1`},
+		{`=0.1+0.2`, `0.3`},
+		{`=1E+20&""`, `1E+20`},
+		{`=123456789012345678&""`, `1.23456789012346E+17`},
+		{`=999999999999999&""`, `999999999999999`},
+		{`=-1E+15&""`, `-1E+15`},
+		{`=1/0`, `#DIV/0!`},
+		{`=0^0`, `#NUM!`},
+		{`=(-8)^0.5`, `#NUM!`},
+		{`="a"&1&TRUE`, `a1TRUE`},
+		{`="abc"="ABC"`, `TRUE`},
+		{`=1<"a"`, `TRUE`},
+		{`="a"<FALSE`, `TRUE`},
+		{`=A1+B2`, `5`},
+		{`=A1&C1`, `1text`},
+		{`=C1+1`, `#VALUE!`},
+		{`=--" -1.5E+2 "`, `-150`},
+		{`="50%"*2`, `1`},
+		{`=--"Infinity"`, `#VALUE!`},
+		{`="inf"+0`, `#VALUE!`},
+		{`="0x10"+0`, `#VALUE!`},
+		{`="1_000"+0`, `#VALUE!`},
+		{`="1E"+0`, `#VALUE!`},
+		{`="."+0`, `#VALUE!`},
+		{`=-"1E+999"`, `#VALUE!`},
+		{`=1E+308*10`, `#NUM!`},
+		{`=-{1E+308}*10`, `{#NUM!}`},
+		{`=INF`, `#NAME?`},
+		{`=Z9=0`, `TRUE`},
+		{`=Z9=""`, `TRUE`},
+		{`=Sheet2!A1*2`, `20`},
+		{`='Sheet2'!A2+1`, `#N/A`},
+		{`=#REF!+1`, `#REF!`},
+		{`=A1:B2*2`, `{2,6;4,8}`},
+		{`={1,2;3,4}+{10;20}`, `{11,12;23,24}`},
+		{`={1,2,3}+{1,2}`, `{2,4,#N/A}`},
+		{`=-{1,"a"}`, `{-1,#VALUE!}`},
+		{`={1,2}&"x"`, `{"1x","2x"}`},
+		{`={"a""b","\"}`, `{"a""b","\"}`},
+		{`=A1:B2 B1:B2`, `{3;"4"}`},
+		{`=A1:A2 B1:B2`, `#NULL!`},
+		{`=A:A 1:1`, `1`},
+		{`=(A1,B1)`, `{1;3}`},
+		{`=(A1,1)`, `#VALUE!`},
+		{`=TaxRate*10`, `5`},
+		{`=Data`, `{1,3;2,"4"}`},
+		{`=Unknown`, `#NAME?`},
+		{`=FOO(1)`, `#NAME?`},
+		{`=[data.xls]Sheet1!A1`, `#REF!`},
+	} {
+		e := NewEvaluator("Sheet1", newTestResolver())
+		v, err := e.EvaluateFormula(c.formula)
+		if err != nil {
+			t.Errorf("%s: %v", c.formula, err)
+			continue
+		}
+		if actual := v.String(); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+	for _, c := range []struct{ formula, expected string }{
+		{`=INDEX(A1:A10,8)`, `0`},
+		{`=INDEX(A1:C2,2,3)`, `0`},
+		{`=COUNTIF(A1:A10,"")`, `8`},
+		{`=SUMIFS(A1:A10,C1:C10,"")`, `2`},
+		{`=A1:C2&""`, `{"1","3","text";"2","4",""}`},
+		{`=(A1:B1,D1:D2)`, `{1;3;;}`},
+	} {
+		e := NewEvaluator("Sheet1", trimmingResolver{newTestResolver()})
+		v, err := e.EvaluateFormula(c.formula)
+		if err != nil || v.String() != c.expected {
+			t.Errorf("%s: expected %s, got %s, %v", c.formula, c.expected, v, err)
+		}
+	}
+	for _, formula := range []string{`=Error!A1`, `=LOOP`, `=SUM(`, `=IF(FALSE,1,Error!A1)`, `=IFERROR(Error!A1,1)`} {
+		e := NewEvaluator("Sheet1", newTestResolver())
+		if _, err := e.EvaluateFormula(formula); err == nil {
+			t.Errorf("%s: expected error", formula)
+		}
+	}
+}
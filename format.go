@@ -0,0 +1,176 @@
+package efp
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatOptions directly maps the options of the formula formatter. Indent is
+// the indentation of the function arguments broken across lines, an empty
+// indent keeps the formula on a single line. The function calls are broken
+// when they exceed the MaxWidth characters, or when they contain nested
+// function calls if MaxWidth is zero. OperatorSpacing adds spaces around the
+// infix operators, ArgumentSpacing adds a space after the argument and array
+// separators, UppercaseFunctions converts the function names to upper case.
+// Minify produces the most compact formula, ignoring the other spacing and
+// indentation options.
+type FormatOptions struct {
+	Indent             string
+	MaxWidth           int
+	OperatorSpacing    bool
+	ArgumentSpacing    bool
+	UppercaseFunctions bool
+	Minify             bool
+}
+
+// formatter directly maps the options of formatting a syntax tree.
+type formatter struct {
+	options FormatOptions
+}
+
+// Format provides function to get the formula text after parsed, formatted
+// with the optional formatter options. Formulas with syntax errors which
+// can't be built as a syntax tree are rendered as is.
+func (ps *Parser) Format(opts ...FormatOptions) string {
+	var options FormatOptions
+	if len(opts) > 0 {
+		options = opts[len(opts)-1]
+	}
+	if options.Minify {
+		options = FormatOptions{UppercaseFunctions: options.UppercaseFunctions, Minify: true}
+	}
+	node, err := BuildTree(ps.Tokens.Items)
+	if err != nil {
+		return ps.Render()
+	}
+	f := formatter{options: options}
+	return f.format(node, 0)
+}
+
+// separator returns the argument separator with the optional space.
+func (f *formatter) separator(sep rune) string {
+	if f.options.ArgumentSpacing {
+		return string(sep) + string(Whitespace)
+	}
+	return string(sep)
+}
+
+// operator returns the text of an infix operator with the optional spaces.
+func (f *formatter) operator(t Token) string {
+	switch {
+	case t.TSubType == TokenSubTypeIntersection:
+		return string(Whitespace)
+	case t.TSubType == TokenSubTypeUnion:
+		return f.separator(Comma)
+	case f.options.OperatorSpacing:
+		return string(Whitespace) + t.TValue + string(Whitespace)
+	}
+	return t.TValue
+}
+
+// functionName returns the function name, in upper case if required, the
+// "_xlfn." and "_xlws." prefixes are kept as is.
+func (f *formatter) functionName(name string) string {
+	if !f.options.UppercaseFunctions {
+		return name
+	}
+	prefix := functionPrefix(name)
+	return prefix + strings.ToUpper(name[len(prefix):])
+}
+
+// containsCall returns whether the node contains a function call.
+func containsCall(n Node) bool {
+	switch n := n.(type) {
+	case *FunctionCall:
+		return true
+	case *UnaryOp:
+		return containsCall(n.Operand)
+	case *PostfixOp:
+		return containsCall(n.Operand)
+	case *BinaryOp:
+		return containsCall(n.Left) || containsCall(n.Right)
+	case *Subexpression:
+		return containsCall(n.Expression)
+	}
+	return false
+}
+
+// breaks returns whether the arguments of the function call at the given
+// nesting depth are broken across lines.
+func (f *formatter) breaks(n *FunctionCall, depth int) bool {
+	if f.options.Indent == "" || len(n.Arguments) == 0 {
+		return false
+	}
+	if f.options.MaxWidth > 0 {
+		width := depth*utf8.RuneCountInString(f.options.Indent) + utf8.RuneCountInString(f.format(n, -1))
+		return width > f.options.MaxWidth
+	}
+	for _, arg := range n.Arguments {
+		if arg != nil && containsCall(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// format provides function to get the formatted text of the node at the given
+// nesting depth, a negative depth keeps the node on a single line.
+func (f *formatter) format(n Node, depth int) string {
+	switch n := n.(type) {
+	case *UnaryOp:
+		return n.Operator.TValue + f.format(n.Operand, depth)
+	case *PostfixOp:
+		return f.format(n.Operand, depth) + n.Operator.TValue
+	case *BinaryOp:
+		return f.format(n.Left, depth) + f.operator(n.Operator) + f.format(n.Right, depth)
+	case *Subexpression:
+		return string(ParenOpen) + f.format(n.Expression, depth) + string(ParenClose)
+	case *ArrayLiteral:
+		rows := make([]string, len(n.Rows))
+		for i, row := range n.Rows {
+			items := make([]string, len(row))
+			for j, item := range row {
+				items[j] = f.format(item, -1)
+			}
+			rows[i] = strings.Join(items, f.separator(Comma))
+		}
+		return string(BraceOpen) + strings.Join(rows, f.separator(Semicolon)) + string(BraceClose)
+	case *FunctionCall:
+		return f.functionCall(n, depth)
+	}
+	return n.String()
+}
+
+// functionCall provides function to get the formatted text of the function
+// call, with each argument on its own indented line when it's broken.
+func (f *formatter) functionCall(n *FunctionCall, depth int) string {
+	var output strings.Builder
+	output.WriteString(f.functionName(n.Name))
+	output.WriteRune(ParenOpen)
+	if depth < 0 || !f.breaks(n, depth) {
+		for i, arg := range n.Arguments {
+			if i > 0 {
+				output.WriteString(f.separator(Comma))
+			}
+			if arg != nil {
+				output.WriteString(f.format(arg, -1))
+			}
+		}
+		output.WriteRune(ParenClose)
+		return output.String()
+	}
+	for i, arg := range n.Arguments {
+		output.WriteRune('\n')
+		output.WriteString(strings.Repeat(f.options.Indent, depth+1))
+		if arg != nil {
+			output.WriteString(f.format(arg, depth+1))
+		}
+		if i < len(n.Arguments)-1 {
+			output.WriteRune(Comma)
+		}
+	}
+	output.WriteRune('\n')
+	output.WriteString(strings.Repeat(f.options.Indent, depth))
+	output.WriteRune(ParenClose)
+	return output.String()
+}
@@ -0,0 +1,31 @@
+package efp
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	for _, c := range []struct {
+		formula  string
+		options  []FormatOptions
+		expected string
+	}{
+		{`=if(A1>0,sum(B1:B3, 2)*-C1%,"a""b")`, nil, `if(A1>0,sum(B1:B3,2)*-C1%,"a""b")`},
+		{`=if(A1>0,sum(B1:B3, 2),{1,2;3,4})`, []FormatOptions{{OperatorSpacing: true, ArgumentSpacing: true, UppercaseFunctions: true}},
+			`IF(A1 > 0, SUM(B1:B3, 2), {1, 2; 3, 4})`},
+		{`= IF( A1 > 0 , _xlfn.xlookup(1,A:A,B:B) )`, []FormatOptions{{OperatorSpacing: true, UppercaseFunctions: true, Minify: true}},
+			`IF(A1>0,_xlfn.XLOOKUP(1,A:A,B:B))`},
+		{`=_xlfn._xlws.ſort(A1:A3)+_xlwſ.sum(1)+_XLFN.zählenwenn(A:A,1)`, []FormatOptions{{UppercaseFunctions: true}},
+			`_xlfn._xlws.SORT(A1:A3)+_XLWS.SUM(1)+_XLFN.ZÄHLENWENN(A:A,1)`},
+		{`=SUM((A1:B2 B1:C3),(A1,B1))`, []FormatOptions{{ArgumentSpacing: true}}, `SUM((A1:B2 B1:C3), (A1, B1))`},
+		{`=IF(A1,SUM(B1,MAX(C1,1)),)`, []FormatOptions{{Indent: "  ", ArgumentSpacing: true}},
+			"IF(\n  A1,\n  SUM(\n    B1,\n    MAX(C1, 1)\n  ),\n  \n)"},
+		{`=IF(A1,SUM(B1,C1),0)+ROUND(A1,0)`, []FormatOptions{{Indent: "\t", MaxWidth: 16}},
+			"IF(\n\tA1,\n\tSUM(B1,C1),\n\t0\n)+ROUND(A1,0)"},
+		{`=SUM(A1`, []FormatOptions{{Indent: "  "}}, `SUM(A1`},
+	} {
+		p := ExcelParser()
+		p.Parse(c.formula)
+		if actual := p.Format(c.options...); actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.formula, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,1386 @@
+package efp
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Function is the implementation of a worksheet function. It receives the
+// values of the arguments with references resolved, an omitted argument is an
+// empty value. Excel errors are returned as error values.
+type Function func(args []Value) Value
+
+// functionRegistry directly maps the worksheet functions by upper case name.
+var functionRegistry = struct {
+	sync.RWMutex
+	functions map[string]Function
+}{functions: map[string]Function{
+	"AND":       fnAnd,
+	"AVERAGE":   fnAverage,
+	"COUNTIF":   fnCountIf,
+	"COUNTIFS":  fnCountIfs,
+	"DATE":      fnDate,
+	"INDEX":     fnIndex,
+	"LEFT":      fnLeft,
+	"LEN":       fnLen,
+	"MATCH":     fnMatch,
+	"MID":       fnMid,
+	"NOT":       fnNot,
+	"OR":        fnOr,
+	"RIGHT":     fnRight,
+	"ROUND":     fnRound,
+	"ROUNDDOWN": fnRoundDown,
+	"ROUNDUP":   fnRoundUp,
+	"SUM":       fnSum,
+	"SUMIF":     fnSumIf,
+	"SUMIFS":    fnSumIfs,
+	"TEXT":      fnText,
+	"VLOOKUP":   fnVLookup,
+	"XLOOKUP":   fnXLookup,
+}}
+
+// RegisterFunction provides function to register a worksheet function for
+// all evaluators, replacing the function with the same name. Names are case
+// insensitive.
+func RegisterFunction(name string, fn Function) {
+	functionRegistry.Lock()
+	defer functionRegistry.Unlock()
+	functionRegistry.functions[strings.ToUpper(name)] = fn
+}
+
+// canonicalFunctionName returns the upper case function name without the
+// "_xlfn." and "_xlws." prefixes stored in files for newer functions.
+func canonicalFunctionName(name string) string {
+	name = strings.ToUpper(name)
+	for _, prefix := range []string{"_XLFN.", "_XLWS."} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
+
+// functionPrefix returns the "_xlfn." and "_xlws." prefixes of the function
+// name in their original case.
+func functionPrefix(name string) string {
+	n := 0
+	for _, prefix := range []string{"_XLFN.", "_XLWS."} {
+		if len(name)-n >= len(prefix) && strings.EqualFold(name[n:n+len(prefix)], prefix) {
+			n += len(prefix)
+		}
+	}
+	return name[:n]
+}
+
+// lookupFunction returns the evaluator or registered function by name.
+func (e *Evaluator) lookupFunction(name string) Function {
+	for _, key := range []string{strings.ToUpper(name), canonicalFunctionName(name)} {
+		if fn, ok := e.Functions[key]; ok {
+			return fn
+		}
+		functionRegistry.RLock()
+		fn, ok := functionRegistry.functions[key]
+		functionRegistry.RUnlock()
+		if ok {
+			return fn
+		}
+	}
+	return nil
+}
+
+// IsReference returns whether the value was resolved from a cell reference.
+// Functions such as SUM ignore texts and logicals in references.
+func (v Value) IsReference() bool {
+	return v.isRef
+}
+
+// flatten returns the items of an array value row by row, or the value
+// itself for a scalar value.
+func flatten(v Value) []Value {
+	if v.Type != ValueTypeArray {
+		return []Value{v}
+	}
+	var items []Value
+	for _, row := range v.Array {
+		items = append(items, row...)
+	}
+	return items
+}
+
+// scalar returns the first item of an array value, or the value itself for a
+// scalar value.
+func scalar(v Value) Value {
+	if v.Type == ValueTypeArray {
+		if len(v.Array) == 0 || len(v.Array[0]) == 0 {
+			return Value{}
+		}
+		return v.Array[0][0]
+	}
+	return v
+}
+
+// numberArg returns the argument coerced to a number.
+func numberArg(v Value) Value {
+	return scalar(v).ToNumber()
+}
+
+// textArg returns the argument coerced to a text.
+func textArg(v Value) Value {
+	return scalar(v).ToText()
+}
+
+// numbers returns the numbers of the arguments in the way aggregate functions
+// do: texts and logicals are coerced when given directly and ignored in
+// references and arrays, the first error is returned as the error value.
+func numbers(args []Value) ([]float64, *Value) {
+	var result []float64
+	for _, arg := range args {
+		direct := arg.Type != ValueTypeArray && !arg.IsReference()
+		for _, item := range flatten(arg) {
+			switch {
+			case item.Type == ValueTypeError:
+				return nil, &item
+			case item.Type == ValueTypeNumber:
+				result = append(result, item.Number)
+			case direct && item.Type != ValueTypeEmpty:
+				n := item.ToNumber()
+				if n.Type == ValueTypeError {
+					return nil, &n
+				}
+				result = append(result, n.Number)
+			}
+		}
+	}
+	return result, nil
+}
+
+// logicals returns the logicals of the arguments in the way AND and OR do,
+// texts in references and arrays are ignored.
+func logicals(args []Value) ([]bool, *Value) {
+	var result []bool
+	for _, arg := range args {
+		direct := arg.Type != ValueTypeArray && !arg.IsReference()
+		for _, item := range flatten(arg) {
+			if item.Type == ValueTypeEmpty || (item.Type == ValueTypeText && !direct) {
+				continue
+			}
+			b := item.ToBool()
+			if b.Type == ValueTypeError {
+				return nil, &b
+			}
+			result = append(result, b.Bool)
+		}
+	}
+	if len(result) == 0 {
+		err := ErrorValue(FormulaErrorVALUE)
+		return nil, &err
+	}
+	return result, nil
+}
+
+// fnSum implements the SUM function.
+func fnSum(args []Value) Value {
+	values, err := numbers(args)
+	if err != nil {
+		return *err
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return NumberValue(sum)
+}
+
+// fnAverage implements the AVERAGE function.
+func fnAverage(args []Value) Value {
+	if len(args) == 0 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	values, err := numbers(args)
+	if err != nil {
+		return *err
+	}
+	if len(values) == 0 {
+		return ErrorValue(FormulaErrorDIV)
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return NumberValue(sum / float64(len(values)))
+}
+
+// lazyFunction returns the implementation of a built-in function which
+// evaluates only the arguments it uses, such as the branch of IF chosen by
+// the condition, so that the arguments not taken are never resolved.
+func (e *Evaluator) lazyFunction(name string) func(args []Node) (Value, error) {
+	switch canonicalFunctionName(name) {
+	case "CHOOSE":
+		return e.fnChoose
+	case "IF":
+		return e.fnIf
+	case "IFERROR":
+		return e.fnIfError
+	case "IFNA":
+		return e.fnIfNA
+	case "IFS":
+		return e.fnIfs
+	case "SWITCH":
+		return e.fnSwitch
+	}
+	return nil
+}
+
+// condition returns the value of the argument coerced to a logical.
+func (e *Evaluator) condition(arg Node) (Value, error) {
+	v, err := e.evalDeref(arg)
+	if err != nil {
+		return v, err
+	}
+	return scalar(v).ToBool(), nil
+}
+
+// branch returns the value of the argument chosen by a function, zero for an
+// omitted or empty argument.
+func (e *Evaluator) branch(arg Node) (Value, error) {
+	v, err := e.evalDeref(arg)
+	return emptyToZero(v), err
+}
+
+// fnIf implements the IF function.
+func (e *Evaluator) fnIf(args []Node) (Value, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	cond, err := e.condition(args[0])
+	if err != nil || cond.Type == ValueTypeError {
+		return cond, err
+	}
+	if cond.Bool {
+		return e.branch(args[1])
+	}
+	if len(args) == 2 {
+		return BoolValue(false), nil
+	}
+	return e.branch(args[2])
+}
+
+// emptyToZero returns zero for an empty value, or the value itself.
+func emptyToZero(v Value) Value {
+	if v.Type == ValueTypeEmpty {
+		return NumberValue(0)
+	}
+	return v
+}
+
+// fnIfs implements the IFS function.
+func (e *Evaluator) fnIfs(args []Node) (Value, error) {
+	if len(args) == 0 || len(args)%2 != 0 {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	for i := 0; i < len(args); i += 2 {
+		cond, err := e.condition(args[i])
+		if err != nil || cond.Type == ValueTypeError {
+			return cond, err
+		}
+		if cond.Bool {
+			return e.branch(args[i+1])
+		}
+	}
+	return ErrorValue(FormulaErrorNA), nil
+}
+
+// fnChoose implements the CHOOSE function.
+func (e *Evaluator) fnChoose(args []Node) (Value, error) {
+	if len(args) < 2 {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	index, err := e.evalDeref(args[0])
+	if err != nil {
+		return index, err
+	}
+	if index = numberArg(index); index.Type == ValueTypeError {
+		return index, nil
+	}
+	i := int(index.Number)
+	if i < 1 || i >= len(args) {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	return e.branch(args[i])
+}
+
+// fnSwitch implements the SWITCH function.
+func (e *Evaluator) fnSwitch(args []Node) (Value, error) {
+	if len(args) < 3 {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	v, err := e.evalDeref(args[0])
+	if err != nil {
+		return v, err
+	}
+	if v = scalar(v); v.Type == ValueTypeError {
+		return v, nil
+	}
+	for i := 1; i+1 < len(args); i += 2 {
+		match, err := e.evalDeref(args[i])
+		if err != nil {
+			return match, err
+		}
+		if match = scalar(match); match.Type == ValueTypeError {
+			return match, nil
+		}
+		if compareValues(v, match) == 0 {
+			return e.branch(args[i+1])
+		}
+	}
+	if len(args)%2 == 0 {
+		return e.branch(args[len(args)-1])
+	}
+	return ErrorValue(FormulaErrorNA), nil
+}
+
+// fnAnd implements the AND function.
+func fnAnd(args []Value) Value {
+	values, err := logicals(args)
+	if err != nil {
+		return *err
+	}
+	for _, v := range values {
+		if !v {
+			return BoolValue(false)
+		}
+	}
+	return BoolValue(true)
+}
+
+// fnOr implements the OR function.
+func fnOr(args []Value) Value {
+	values, err := logicals(args)
+	if err != nil {
+		return *err
+	}
+	for _, v := range values {
+		if v {
+			return BoolValue(true)
+		}
+	}
+	return BoolValue(false)
+}
+
+// fnNot implements the NOT function.
+func fnNot(args []Value) Value {
+	if len(args) != 1 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	b := scalar(args[0]).ToBool()
+	if b.Type == ValueTypeError {
+		return b
+	}
+	return BoolValue(!b.Bool)
+}
+
+// fnIfError implements the IFERROR function.
+func (e *Evaluator) fnIfError(args []Node) (Value, error) {
+	if len(args) != 2 {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	v, err := e.branch(args[0])
+	if err != nil || v.Type != ValueTypeError {
+		return v, err
+	}
+	return e.branch(args[1])
+}
+
+// fnIfNA implements the IFNA function.
+func (e *Evaluator) fnIfNA(args []Node) (Value, error) {
+	if len(args) != 2 {
+		return ErrorValue(FormulaErrorVALUE), nil
+	}
+	v, err := e.branch(args[0])
+	if err != nil || v.Type != ValueTypeError || v.Text != FormulaErrorNA {
+		return v, err
+	}
+	return e.branch(args[1])
+}
+
+// matchWildcard returns whether the text matches the pattern case
+// insensitively, "*" matches any sequence of characters, "?" matches any
+// single character and "~" escapes the next character. The pattern is
+// matched by two pointers, which go back to the last "*" on a mismatch.
+func matchWildcard(pattern, text string) bool {
+	var (
+		p, t    = []rune(strings.ToUpper(pattern)), []rune(strings.ToUpper(text))
+		literal = make([]bool, 0, len(p))
+		runes   = p[:0]
+	)
+	for i := 0; i < len(p); i++ {
+		escaped := p[i] == '~' && i+1 < len(p)
+		if escaped {
+			i++
+		}
+		runes, literal = append(runes, p[i]), append(literal, escaped)
+	}
+	p = runes
+	i, j, star, mark := 0, 0, -1, 0
+	for j < len(t) {
+		switch {
+		case i < len(p) && p[i] == '*' && !literal[i]:
+			star, mark = i, j
+			i++
+		case i < len(p) && ((p[i] == '?' && !literal[i]) || p[i] == t[j]):
+			i++
+			j++
+		case star != -1:
+			mark++
+			i, j = star+1, mark
+		default:
+			return false
+		}
+	}
+	for i < len(p) && p[i] == '*' && !literal[i] {
+		i++
+	}
+	return i == len(p)
+}
+
+// lookupEqual returns whether the item equals the lookup value, texts are
+// matched with wildcards when enabled.
+func lookupEqual(lookup, item Value, wildcard bool) bool {
+	if wildcard && lookup.Type == ValueTypeText && item.Type == ValueTypeText {
+		return matchWildcard(lookup.Text, item.Text)
+	}
+	return lookup.Type == item.Type && compareValues(lookup, item) == 0 ||
+		lookup.Type == ValueTypeEmpty && item.Type == ValueTypeEmpty
+}
+
+// vector returns the items of a single row or single column array.
+func vector(v Value) ([]Value, bool) {
+	rows, cols := arraySize(v)
+	if rows != 1 && cols != 1 {
+		return nil, false
+	}
+	items := flatten(v)
+	for len(items) < rows*cols {
+		items = append(items, Value{})
+	}
+	return items, true
+}
+
+// findPosition returns the index of the lookup value in the items. Match type
+// 0 finds an exact match, 1 finds the largest item less than or equal to the
+// lookup value in ascending items, -1 finds the smallest item greater than or
+// equal to the lookup value in descending items.
+func findPosition(lookup Value, items []Value, matchType int) int {
+	found := -1
+	for i, item := range items {
+		if matchType == 0 {
+			if lookupEqual(lookup, item, true) {
+				return i
+			}
+			continue
+		}
+		if item.Type == ValueTypeEmpty || typeRank(item) != typeRank(lookup) {
+			continue
+		}
+		cmp := compareValues(item, lookup) * matchType
+		if cmp > 0 {
+			break
+		}
+		found = i
+		if cmp == 0 {
+			return i
+		}
+	}
+	return found
+}
+
+// fnVLookup implements the VLOOKUP function.
+func fnVLookup(args []Value) Value {
+	if len(args) < 3 || len(args) > 4 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	lookup := scalar(args[0])
+	if lookup.Type == ValueTypeError {
+		return lookup
+	}
+	col := numberArg(args[2])
+	if col.Type == ValueTypeError {
+		return col
+	}
+	approximate := true
+	if len(args) == 4 {
+		b := scalar(args[3]).ToBool()
+		if b.Type == ValueTypeError {
+			return b
+		}
+		approximate = b.Bool
+	}
+	table := args[1]
+	if table.Type != ValueTypeArray {
+		table = ArrayValue([][]Value{{table}})
+	}
+	index := int(col.Number)
+	if index < 1 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	_, cols := arraySize(table)
+	if index > cols {
+		return ErrorValue(FormulaErrorREF)
+	}
+	firstCol := make([]Value, len(table.Array))
+	for i := range table.Array {
+		firstCol[i] = arrayItem(table, i, 0)
+	}
+	matchType := 0
+	if approximate {
+		matchType = 1
+	}
+	row := findPosition(lookup, firstCol, matchType)
+	if row == -1 {
+		return ErrorValue(FormulaErrorNA)
+	}
+	return emptyToZero(arrayItem(table, row, index-1))
+}
+
+// fnXLookup implements the XLOOKUP function.
+func fnXLookup(args []Value) Value {
+	if len(args) < 3 || len(args) > 6 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	lookup := scalar(args[0])
+	if lookup.Type == ValueTypeError {
+		return lookup
+	}
+	items, ok := vector(args[1])
+	if !ok {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	modes := [2]int{0, 1}
+	for i := range modes {
+		if len(args) > 4+i && args[4+i].Type != ValueTypeEmpty {
+			mode := numberArg(args[4+i])
+			if mode.Type == ValueTypeError {
+				return mode
+			}
+			modes[i] = int(mode.Number)
+		}
+	}
+	matchMode, searchMode := modes[0], modes[1]
+	if matchMode < -1 || matchMode > 2 || searchMode == 0 || searchMode < -2 || searchMode > 2 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	found, best := -1, Value{}
+	for k := range items {
+		i := k
+		if searchMode < 0 {
+			i = len(items) - 1 - k
+		}
+		item := items[i]
+		if lookupEqual(lookup, item, matchMode == 2) {
+			found = i
+			break
+		}
+		if matchMode == 0 || matchMode == 2 || item.Type == ValueTypeEmpty || typeRank(item) != typeRank(lookup) {
+			continue
+		}
+		cmp := compareValues(item, lookup)
+		if cmp*matchMode > 0 && (found == -1 || compareValues(item, best)*matchMode < 0) {
+			found, best = i, item
+		}
+	}
+	if found == -1 {
+		if len(args) > 3 && args[3].Type != ValueTypeEmpty {
+			return args[3]
+		}
+		return ErrorValue(FormulaErrorNA)
+	}
+	result := args[2]
+	if result.Type != ValueTypeArray {
+		return result
+	}
+	rows, cols := arraySize(args[1])
+	if rows == 1 && cols > 1 {
+		column := make([][]Value, len(result.Array))
+		for i := range result.Array {
+			column[i] = []Value{arrayItem(result, i, found)}
+		}
+		if len(column) == 1 {
+			return emptyToZero(column[0][0])
+		}
+		return ArrayValue(column)
+	}
+	if found >= len(result.Array) {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	if row := result.Array[found]; len(row) != 1 {
+		return ArrayValue([][]Value{row})
+	}
+	return emptyToZero(result.Array[found][0])
+}
+
+// fnIndex implements the INDEX function.
+func fnIndex(args []Value) Value {
+	if len(args) < 2 || len(args) > 3 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	array := args[0]
+	if array.Type != ValueTypeArray {
+		array = ArrayValue([][]Value{{array}})
+	}
+	indexes := [2]int{}
+	for i := range indexes {
+		if len(args) > i+1 && args[i+1].Type != ValueTypeEmpty {
+			n := numberArg(args[i+1])
+			if n.Type == ValueTypeError {
+				return n
+			}
+			if indexes[i] = int(n.Number); indexes[i] < 0 {
+				return ErrorValue(FormulaErrorVALUE)
+			}
+		}
+	}
+	row, col := indexes[0], indexes[1]
+	rows, cols := arraySize(array)
+	if len(args) == 2 && rows == 1 {
+		row, col = 1, row
+	} else if len(args) == 2 && cols == 1 {
+		col = 1
+	}
+	if row > rows || col > cols {
+		return ErrorValue(FormulaErrorREF)
+	}
+	switch {
+	case row > 0 && col > 0:
+		return emptyToZero(arrayItem(array, row-1, col-1))
+	case row > 0:
+		return ArrayValue([][]Value{array.Array[row-1]})
+	case col > 0:
+		column := make([][]Value, rows)
+		for i := range column {
+			column[i] = []Value{arrayItem(array, i, col-1)}
+		}
+		return ArrayValue(column)
+	}
+	return array
+}
+
+// fnMatch implements the MATCH function.
+func fnMatch(args []Value) Value {
+	if len(args) < 2 || len(args) > 3 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	lookup := scalar(args[0])
+	if lookup.Type == ValueTypeError {
+		return lookup
+	}
+	items, ok := vector(args[1])
+	if !ok {
+		return ErrorValue(FormulaErrorNA)
+	}
+	matchType := 1
+	if len(args) == 3 {
+		n := numberArg(args[2])
+		if n.Type == ValueTypeError {
+			return n
+		}
+		switch {
+		case n.Number > 0:
+			matchType = 1
+		case n.Number < 0:
+			matchType = -1
+		default:
+			matchType = 0
+		}
+	}
+	position := findPosition(lookup, items, matchType)
+	if position == -1 {
+		return ErrorValue(FormulaErrorNA)
+	}
+	return NumberValue(float64(position + 1))
+}
+
+// criteria directly maps a condition of the COUNTIF and SUMIF family
+// functions, such as ">=10" or "a*".
+type criteria struct {
+	op    string
+	value Value
+}
+
+// parseCriteria parses the criteria argument value.
+func parseCriteria(v Value) criteria {
+	v = scalar(v)
+	if v.Type != ValueTypeText {
+		return criteria{op: "=", value: v}
+	}
+	c := criteria{op: "="}
+	text := v.Text
+	for _, op := range []string{">=", "<=", "<>", ">", "<", "="} {
+		if strings.HasPrefix(text, op) {
+			c.op, text = op, text[len(op):]
+			break
+		}
+	}
+	if n := TextValue(text).ToNumber(); n.Type == ValueTypeNumber {
+		c.value = n
+	} else if b := TextValue(text).ToBool(); b.Type == ValueTypeLogical {
+		c.value = b
+	} else if text != "" {
+		c.value = TextValue(text)
+	}
+	return c
+}
+
+// match returns whether the cell value meets the criteria.
+func (c criteria) match(v Value) bool {
+	if c.value.Type == ValueTypeEmpty {
+		empty := v.Type == ValueTypeEmpty || (v.Type == ValueTypeText && v.Text == "")
+		if c.op == "<>" {
+			return !empty
+		}
+		return empty && c.op == "="
+	}
+	if c.value.Type == ValueTypeText && (c.op == "=" || c.op == "<>") {
+		matched := v.Type == ValueTypeText && matchWildcard(c.value.Text, v.Text)
+		return matched == (c.op == "=")
+	}
+	if v.Type != c.value.Type {
+		return c.op == "<>"
+	}
+	return compareResult(c.op, compareValues(v, c.value))
+}
+
+// matchCriteria returns the positions of the items meeting all criteria
+// given as pairs of range and criteria arguments.
+func matchCriteria(args []Value) ([]bool, *Value) {
+	if len(args) == 0 || len(args)%2 != 0 {
+		err := ErrorValue(FormulaErrorVALUE)
+		return nil, &err
+	}
+	var matched []bool
+	for i := 0; i < len(args); i += 2 {
+		items, c := flatten(args[i]), parseCriteria(args[i+1])
+		if matched == nil {
+			matched = make([]bool, len(items))
+			for j := range matched {
+				matched[j] = true
+			}
+		}
+		if len(items) != len(matched) {
+			err := ErrorValue(FormulaErrorVALUE)
+			return nil, &err
+		}
+		for j, item := range items {
+			matched[j] = matched[j] && c.match(item)
+		}
+	}
+	return matched, nil
+}
+
+// countMatched returns the number of matched positions.
+func countMatched(matched []bool) Value {
+	count := 0
+	for _, m := range matched {
+		if m {
+			count++
+		}
+	}
+	return NumberValue(float64(count))
+}
+
+// sumMatched returns the sum of the numbers at the matched positions.
+func sumMatched(values Value, matched []bool) Value {
+	var sum float64
+	items := flatten(values)
+	for i, m := range matched {
+		if !m || i >= len(items) {
+			continue
+		}
+		if items[i].Type == ValueTypeError {
+			return items[i]
+		}
+		if items[i].Type == ValueTypeNumber {
+			sum += items[i].Number
+		}
+	}
+	return NumberValue(sum)
+}
+
+// fnCountIf implements the COUNTIF function.
+func fnCountIf(args []Value) Value {
+	if len(args) != 2 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	return fnCountIfs(args)
+}
+
+// fnCountIfs implements the COUNTIFS function.
+func fnCountIfs(args []Value) Value {
+	matched, err := matchCriteria(args)
+	if err != nil {
+		return *err
+	}
+	return countMatched(matched)
+}
+
+// fnSumIf implements the SUMIF function.
+func fnSumIf(args []Value) Value {
+	if len(args) < 2 || len(args) > 3 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	matched, err := matchCriteria(args[:2])
+	if err != nil {
+		return *err
+	}
+	if len(args) == 3 && args[2].Type != ValueTypeEmpty {
+		return sumMatched(args[2], matched)
+	}
+	return sumMatched(args[0], matched)
+}
+
+// fnSumIfs implements the SUMIFS function.
+func fnSumIfs(args []Value) Value {
+	if len(args) < 3 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	matched, err := matchCriteria(args[1:])
+	if err != nil {
+		return *err
+	}
+	if len(flatten(args[0])) != len(matched) {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	return sumMatched(args[0], matched)
+}
+
+// clampNumber returns the integer part of the number clamped to the given
+// range, the number is clamped before the conversion to avoid the overflow
+// of huge numbers, such as 1E+300.
+func clampNumber(n float64, lo, hi int) int {
+	return int(math.Max(float64(lo), math.Min(float64(hi), n)))
+}
+
+// textCount returns the text argument and the optional count argument of
+// the LEFT and RIGHT functions, the count is clamped to the length of the
+// text.
+func textCount(args []Value) ([]rune, int, *Value) {
+	if len(args) < 1 || len(args) > 2 {
+		err := ErrorValue(FormulaErrorVALUE)
+		return nil, 0, &err
+	}
+	text := textArg(args[0])
+	if text.Type == ValueTypeError {
+		return nil, 0, &text
+	}
+	runes := []rune(text.Text)
+	count := minInt(1, len(runes))
+	if len(args) == 2 {
+		n := numberArg(args[1])
+		if n.Type == ValueTypeError {
+			return nil, 0, &n
+		}
+		if n.Number < 0 {
+			err := ErrorValue(FormulaErrorVALUE)
+			return nil, 0, &err
+		}
+		count = clampNumber(n.Number, 0, len(runes))
+	}
+	return runes, count, nil
+}
+
+// fnLeft implements the LEFT function.
+func fnLeft(args []Value) Value {
+	runes, count, err := textCount(args)
+	if err != nil {
+		return *err
+	}
+	return TextValue(string(runes[:count]))
+}
+
+// fnRight implements the RIGHT function.
+func fnRight(args []Value) Value {
+	runes, count, err := textCount(args)
+	if err != nil {
+		return *err
+	}
+	return TextValue(string(runes[len(runes)-count:]))
+}
+
+// fnMid implements the MID function.
+func fnMid(args []Value) Value {
+	if len(args) != 3 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	text := textArg(args[0])
+	if text.Type == ValueTypeError {
+		return text
+	}
+	start, count := numberArg(args[1]), numberArg(args[2])
+	if start.Type == ValueTypeError {
+		return start
+	}
+	if count.Type == ValueTypeError {
+		return count
+	}
+	if start.Number < 1 || count.Number < 0 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	runes := []rune(text.Text)
+	from := clampNumber(start.Number-1, 0, len(runes))
+	to := from + clampNumber(count.Number, 0, len(runes)-from)
+	return TextValue(string(runes[from:to]))
+}
+
+// fnLen implements the LEN function.
+func fnLen(args []Value) Value {
+	if len(args) != 1 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	text := textArg(args[0])
+	if text.Type == ValueTypeError {
+		return text
+	}
+	return NumberValue(float64(utf8.RuneCountInString(text.Text)))
+}
+
+// excelEpoch is the date of the serial number zero in the 1900 date system.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// dateToSerial returns the serial number of the date in the 1900 date system,
+// which treats 1900 as a leap year. The seconds are counted without the
+// time.Duration, which overflows after about 292 years.
+func dateToSerial(t time.Time) float64 {
+	serial := (float64(t.Unix()-excelEpoch.Unix()) + float64(t.Nanosecond())/1e9) / 86400
+	if serial < 61 {
+		serial--
+	}
+	return serial
+}
+
+// serialToDate returns the date of the serial number in the 1900 date system.
+func serialToDate(serial float64) time.Time {
+	if serial < 61 {
+		serial++
+	}
+	days := math.Floor(serial)
+	t := excelEpoch.AddDate(0, 0, int(days))
+	return t.Add(time.Duration(math.Round((serial-days)*86400)) * time.Second)
+}
+
+// maxDateSerial is the serial number of the last date in the 1900 date
+// system, 9999-12-31. The arguments of the DATE function beyond it are out of
+// the range of the dates.
+const maxDateSerial = 2958465
+
+// fnDate implements the DATE function.
+func fnDate(args []Value) Value {
+	if len(args) != 3 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	var parts [3]int
+	for i, arg := range args {
+		n := numberArg(arg)
+		if n.Type == ValueTypeError {
+			return n
+		}
+		if math.Abs(n.Number) > maxDateSerial {
+			return ErrorValue(FormulaErrorNUM)
+		}
+		parts[i] = int(n.Number)
+	}
+	year := parts[0]
+	if year < 1900 {
+		year += 1900
+	}
+	if year < 1900 || year > 9999 {
+		return ErrorValue(FormulaErrorNUM)
+	}
+	serial := dateToSerial(time.Date(year, time.Month(parts[1]), parts[2], 0, 0, 0, 0, time.UTC))
+	if year == 1900 && parts[1] == 2 && parts[2] == 29 {
+		serial = 60
+	}
+	if serial < 0 || serial > maxDateSerial {
+		return ErrorValue(FormulaErrorNUM)
+	}
+	return NumberValue(serial)
+}
+
+// maxRoundDigits is the largest number of digits which can be rounded to,
+// the scale of more digits is out of the range of float64.
+const maxRoundDigits = 308
+
+// roundDigits rounds the number to the given number of digits with the
+// rounding function, the scaled number is rounded to 15 significant digits
+// first to avoid binary representation errors, such as 2.675 * 100. The
+// number is returned unchanged if it has no digits beyond the given one.
+func roundDigits(number float64, digits int, round func(float64) float64) float64 {
+	scale := math.Pow(10, float64(digits))
+	scaled, _ := strconv.ParseFloat(strconv.FormatFloat(number*scale, 'g', 15, 64), 64)
+	if math.IsInf(scaled, 0) {
+		return number
+	}
+	return round(scaled) / scale
+}
+
+// roundFunction returns the implementation of a ROUND family function, the
+// number of digits is clamped to the range of float64.
+func roundFunction(round func(float64) float64) Function {
+	return func(args []Value) Value {
+		if len(args) != 2 {
+			return ErrorValue(FormulaErrorVALUE)
+		}
+		number, digits := numberArg(args[0]), numberArg(args[1])
+		if number.Type == ValueTypeError {
+			return number
+		}
+		if digits.Type == ValueTypeError {
+			return digits
+		}
+		result := roundDigits(number.Number, clampNumber(digits.Number, -maxRoundDigits, maxRoundDigits), round)
+		if math.IsInf(result, 0) || math.IsNaN(result) {
+			return ErrorValue(FormulaErrorNUM)
+		}
+		return NumberValue(result)
+	}
+}
+
+var (
+	fnRound     = roundFunction(math.Round)
+	fnRoundDown = roundFunction(math.Trunc)
+	fnRoundUp   = roundFunction(func(x float64) float64 {
+		if x < 0 {
+			return math.Floor(x)
+		}
+		return math.Ceil(x)
+	})
+)
+
+// fnText implements the TEXT function.
+func fnText(args []Value) Value {
+	if len(args) != 2 {
+		return ErrorValue(FormulaErrorVALUE)
+	}
+	value, format := scalar(args[0]), textArg(args[1])
+	if value.Type == ValueTypeError {
+		return value
+	}
+	if format.Type == ValueTypeError {
+		return format
+	}
+	number := value.ToNumber()
+	if number.Type == ValueTypeError {
+		return value.ToText()
+	}
+	return TextValue(formatValue(number.Number, format.Text))
+}
+
+// formatValue returns the number formatted with a number format code, such
+// as "#,##0.00", "0%", "0.00E+00" or "yyyy-mm-dd".
+func formatValue(number float64, format string) string {
+	sections := splitFormat(format)
+	section := sections[0]
+	switch {
+	case number < 0 && len(sections) > 1:
+		section, number = sections[1], -number
+	case number == 0 && len(sections) > 2:
+		section = sections[2]
+	}
+	if strings.EqualFold(section, "General") || section == "" || section == "@" {
+		return formatNumber(number)
+	}
+	if isDateFormat(section) {
+		return formatDate(serialToDate(number), section)
+	}
+	return formatDecimal(number, section)
+}
+
+// splitFormat splits the number format code into sections separated by
+// semicolons outside of quoted literals.
+func splitFormat(format string) []string {
+	var (
+		sections []string
+		quoted   bool
+		start    int
+	)
+	for i := 0; i < len(format); i++ {
+		switch format[i] {
+		case '"':
+			quoted = !quoted
+		case '\\':
+			i++
+		case ';':
+			if !quoted {
+				sections = append(sections, format[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(sections, format[start:])
+}
+
+// formatLiterals returns the format section with the quoted and escaped
+// literals replaced by NUL characters, and the literals in order.
+func formatLiterals(section string) (string, []string) {
+	var (
+		code     strings.Builder
+		literals []string
+	)
+	for i := 0; i < len(section); i++ {
+		switch c := section[i]; {
+		case c == '"':
+			end := strings.IndexByte(section[i+1:], '"')
+			if end == -1 {
+				end = len(section) - i - 1
+			}
+			literals = append(literals, section[i+1:i+1+end])
+			code.WriteByte(0)
+			i += end + 1
+		case c == '\\' && i+1 < len(section):
+			_, size := utf8.DecodeRuneInString(section[i+1:])
+			literals = append(literals, section[i+1:i+1+size])
+			code.WriteByte(0)
+			i += size
+		case (c == '_' || c == '*') && i+1 < len(section):
+			// padding is a space and repeated characters are omitted in texts
+			if c == '_' {
+				literals = append(literals, " ")
+			} else {
+				literals = append(literals, "")
+			}
+			code.WriteByte(0)
+			i++
+		case c == '[':
+			if end := strings.IndexByte(section[i:], ']'); end != -1 {
+				i += end
+			}
+		default:
+			code.WriteByte(c)
+		}
+	}
+	return code.String(), literals
+}
+
+// isDateFormat returns whether the format section contains date or time
+// codes.
+func isDateFormat(section string) bool {
+	code, _ := formatLiterals(section)
+	return strings.ContainsAny(strings.ToLower(code), "ydhs")
+}
+
+// formatDate returns the date formatted with a date and time format section.
+func formatDate(t time.Time, section string) string {
+	code, literals := formatLiterals(section)
+	lower := strings.ToLower(code)
+	ampm := strings.Contains(lower, "am/pm") || strings.Contains(lower, "a/p")
+	var output strings.Builder
+	for i := 0; i < len(code); {
+		n := 1
+		for i+n < len(lower) && lower[i+n] == lower[i] {
+			n++
+		}
+		switch c := lower[i]; {
+		case c == 0:
+			output.WriteString(literals[0])
+			literals = literals[1:]
+			n = 1
+		case c == 'y':
+			if n <= 2 {
+				output.WriteString(padNumber(t.Year()%100, 2))
+			} else {
+				output.WriteString(strconv.Itoa(t.Year()))
+			}
+		case c == 'm' && isMinute(lower, i, n):
+			output.WriteString(padNumber(t.Minute(), n))
+		case c == 'm':
+			output.WriteString(formatMonth(t.Month(), n))
+		case c == 'd':
+			switch {
+			case n >= 4:
+				output.WriteString(t.Weekday().String())
+			case n == 3:
+				output.WriteString(t.Weekday().String()[:3])
+			default:
+				output.WriteString(padNumber(t.Day(), n))
+			}
+		case c == 'h':
+			hour := t.Hour()
+			if ampm {
+				if hour = hour % 12; hour == 0 {
+					hour = 12
+				}
+			}
+			output.WriteString(padNumber(hour, n))
+		case c == 's':
+			output.WriteString(padNumber(t.Second(), n))
+		case strings.HasPrefix(lower[i:], "am/pm"):
+			if t.Hour() < 12 {
+				output.WriteString("AM")
+			} else {
+				output.WriteString("PM")
+			}
+			n = 5
+		case strings.HasPrefix(lower[i:], "a/p"):
+			if t.Hour() < 12 {
+				output.WriteString("A")
+			} else {
+				output.WriteString("P")
+			}
+			n = 3
+		default:
+			output.WriteString(code[i : i+n])
+		}
+		i += n
+	}
+	return output.String()
+}
+
+// isMinute returns whether the "m" code at the given position means minutes,
+// which is the case after an hour code or before a second code.
+func isMinute(lower string, i, n int) bool {
+	before := strings.TrimRight(lower[:i], ": ")
+	after := strings.TrimLeft(lower[i+n:], ": ")
+	return strings.HasSuffix(before, "h") || strings.HasPrefix(after, "s")
+}
+
+// padNumber returns the number padded with a zero to two digits when the
+// code has two or more characters.
+func padNumber(number, n int) string {
+	if n >= 2 && number < 10 {
+		return "0" + strconv.Itoa(number)
+	}
+	return strconv.Itoa(number)
+}
+
+// formatMonth returns the month as number, abbreviation or full name for
+// the given code length.
+func formatMonth(month time.Month, n int) string {
+	switch {
+	case n >= 5:
+		return month.String()[:1]
+	case n == 4:
+		return month.String()
+	case n == 3:
+		return month.String()[:3]
+	}
+	return padNumber(int(month), n)
+}
+
+// formatDecimal returns the number formatted with a number format section,
+// such as "#,##0.00", "0%" or "0.00E+00".
+func formatDecimal(number float64, section string) string {
+	code, literals := formatLiterals(section)
+	var exponent string
+	if i := strings.IndexAny(code, "Ee"); i != -1 && i+1 < len(code) && (code[i+1] == '+' || code[i+1] == '-') {
+		code, exponent = code[:i], code[i:]
+	}
+	first, last := strings.IndexAny(code, "0#?"), strings.LastIndexAny(code, "0#?")
+	if first == -1 {
+		return replaceLiterals(code+exponent, literals)
+	}
+	if first > 0 && code[first-1] == '.' {
+		first--
+	}
+	prefix, body, suffix := code[:first], code[first:last+1], code[last+1:]
+	for i := strings.Count(code, "%"); i > 0; i-- {
+		number *= 100
+	}
+	negative := number < 0
+	number = math.Abs(number)
+	integer, fraction := body, ""
+	dot := strings.IndexByte(body, '.')
+	if dot != -1 {
+		integer, fraction = body[:dot], body[dot+1:]
+	}
+	var text string
+	if exponent != "" {
+		text, suffix = formatScientific(number, integer, fraction, dot != -1, exponent)
+	} else {
+		text = formatFixed(number, integer, fraction, dot != -1)
+	}
+	if negative && strings.Trim(text, "0.,E+-") != "" {
+		text = "-" + text
+	}
+	return replaceLiterals(prefix+text+suffix, literals)
+}
+
+// replaceLiterals returns the format code with the NUL characters replaced by
+// the literals in order.
+func replaceLiterals(code string, literals []string) string {
+	var output strings.Builder
+	for i := 0; i < len(code); i++ {
+		if code[i] == 0 && len(literals) > 0 {
+			output.WriteString(literals[0])
+			literals = literals[1:]
+			continue
+		}
+		output.WriteByte(code[i])
+	}
+	return output.String()
+}
+
+// formatFixed returns the number formatted with the integer and fraction
+// placeholders of a number format section.
+func formatFixed(number float64, integer, fraction string, dot bool) string {
+	minDigits := strings.Count(fraction, "0")
+	maxDigits := minDigits + strings.Count(fraction, "#") + strings.Count(fraction, "?")
+	digits := strconv.FormatFloat(roundDigits(number, maxDigits, math.Round), 'f', maxDigits, 64)
+	intPart, fracPart := digits, ""
+	if maxDigits > 0 {
+		intPart, fracPart = digits[:len(digits)-maxDigits-1], digits[len(digits)-maxDigits:]
+		for len(fracPart) > minDigits && strings.HasSuffix(fracPart, "0") {
+			fracPart = fracPart[:len(fracPart)-1]
+		}
+	}
+	intZeros := strings.Count(integer, "0")
+	if intPart == "0" && intZeros == 0 {
+		intPart = ""
+	}
+	for len(intPart) < intZeros {
+		intPart = "0" + intPart
+	}
+	if strings.Contains(integer, ",") && len(intPart) > 3 {
+		var groups []string
+		for len(intPart) > 3 {
+			groups = append([]string{intPart[len(intPart)-3:]}, groups...)
+			intPart = intPart[:len(intPart)-3]
+		}
+		intPart = strings.Join(append([]string{intPart}, groups...), ",")
+	}
+	if dot {
+		return intPart + "." + fracPart
+	}
+	return intPart
+}
+
+// formatScientific returns the number formatted in scientific notation, and
+// the remaining suffix after the exponent placeholders, such as "E+00".
+func formatScientific(number float64, integer, fraction string, dot bool, suffix string) (string, string) {
+	digits := strings.Count(fraction, "0") + strings.Count(fraction, "#")
+	sign := suffix[1]
+	expDigits := 0
+	for expDigits+2 < len(suffix) && strings.IndexByte("0#", suffix[expDigits+2]) != -1 {
+		expDigits++
+	}
+	exponent := 0
+	if number != 0 {
+		exponent = int(math.Floor(math.Log10(number)))
+	}
+	mantissa := roundDigits(number/math.Pow(10, float64(exponent)), digits, math.Round)
+	if mantissa >= 10 {
+		mantissa /= 10
+		exponent++
+	}
+	text := formatFixed(mantissa, integer, fraction, dot) + "E"
+	if exponent < 0 {
+		text += "-"
+		exponent = -exponent
+	} else if sign == '+' {
+		text += "+"
+	}
+	exp := strconv.Itoa(exponent)
+	for len(exp) < expDigits {
+		exp = "0" + exp
+	}
+	return text + exp, suffix[expDigits+2:]
+}
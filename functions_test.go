@@ -0,0 +1,196 @@
+package efp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFunctions(t *testing.T) {
+	resolver := newTestResolver()
+	for cell, v := range map[string]Value{
+		"D1": TextValue("Apple"), "E1": NumberValue(10), "F1": TextValue("East"),
+		"D2": TextValue("Banana"), "E2": NumberValue(20), "F2": TextValue("West"),
+		"D3": TextValue("Cherry"), "E3": NumberValue(30), "F3": TextValue("East"),
+		"D4": TextValue("apricot"), "E4": BoolValue(true), "F4": TextValue("East"),
+	} {
+		resolver.cells["Sheet1!"+cell] = v
+	}
+	for _, c := range []struct{ formula, expected string }{
+		{`=SUM(1,"2",TRUE)`, `4`},
+		{`=SUM(A1:C1)`, `4`},
+		{`=SUM(C1)`, `0`},
+		{`=SUM("a")`, `#VALUE!`},
+		{`=SUM(A1,Sheet2!A2)`, `#N/A`},
+		{`=SUM((A1,B1),{1,2})`, `7`},
+		{`=AVERAGE(A1:B2)`, `2`},
+		{`=AVERAGE(C1)`, `#DIV/0!`},
+		{`=IF(A1>0,"yes","no")`, `yes`},
+		{`=IF(FALSE,1)`, `FALSE`},
+		{`=IF(TRUE,)`, `0`},
+		{`=IF("x",1,2)`, `#VALUE!`},
+		{`=_xlfn.IFS(A1>1,"a",A1=1,"b")`, `b`},
+		{`=IFS(FALSE,1)`, `#N/A`},
+		{`=AND(TRUE,1,A1:C1)`, `TRUE`},
+		{`=OR(FALSE,0)`, `FALSE`},
+		{`=AND(C1)`, `#VALUE!`},
+		{`=NOT(0)`, `TRUE`},
+		{`=IFERROR(1/0,"err")`, `err`},
+		{`=IFERROR(5,"err")`, `5`},
+		{`=IF(TRUE,1,Error!A1)`, `1`},
+		{`=IF(FALSE,Error!A1,2)`, `2`},
+		{`=IFS(TRUE,1,Error!A1,2)`, `1`},
+		{`=IFERROR(1,Error!A1)`, `1`},
+		{`=_xlfn.IFNA(VLOOKUP("x",D1:E4,2,FALSE),"none")`, `none`},
+		{`=IFNA(1/0,"none")`, `#DIV/0!`},
+		{`=IFNA(1,Error!A1)`, `1`},
+		{`=CHOOSE(2,Error!A1,"b",Error!A2)`, `b`},
+		{`=CHOOSE(3,"a","b")`, `#VALUE!`},
+		{`=CHOOSE("x","a")`, `#VALUE!`},
+		{`=_xlfn.SWITCH(A1,2,"two",1,"one",Error!A1)`, `one`},
+		{`=SWITCH("b","A",1,"B",2)`, `2`},
+		{`=SWITCH(3,1,"one","other")`, `other`},
+		{`=SWITCH(3,1,"one")`, `#N/A`},
+		{`=SWITCH(#N/A,1,"one")`, `#N/A`},
+		{`=VLOOKUP("banana",D1:E4,2,FALSE)`, `20`},
+		{`=VLOOKUP("b*",D1:E4,2,FALSE)`, `20`},
+		{`=VLOOKUP("Zebra",D1:E4,2,FALSE)`, `#N/A`},
+		{`=VLOOKUP(25,{10,"a";20,"b";30,"c"},2)`, `b`},
+		{`=VLOOKUP(5,{10,"a"},2)`, `#N/A`},
+		{`=VLOOKUP(10,{10,"a"},3)`, `#REF!`},
+		{`=XLOOKUP("Cherry",D1:D4,E1:E4)`, `30`},
+		{`=XLOOKUP("x",D1:D4,E1:E4,"none")`, `none`},
+		{`=XLOOKUP(25,E1:E3,D1:D3,,1)`, `Cherry`},
+		{`=XLOOKUP(25,E1:E3,D1:D3,,-1)`, `Banana`},
+		{`=XLOOKUP("East",F1:F4,D1:D4,,0,-1)`, `apricot`},
+		{`=XLOOKUP("a*",D1:D4,E1:E4,,2)`, `10`},
+		{`=XLOOKUP(2,{1,2,3},{"a","b","c"})`, `b`},
+		{`=XLOOKUP("Banana",D1:D4,D1:F4)`, `{"Banana",20,"West"}`},
+		{`=INDEX(D1:F4,2,3)`, `West`},
+		{`=INDEX({1,2,3},2)`, `2`},
+		{`=INDEX(E1:E4,2)`, `20`},
+		{`=INDEX({1;2;3},3)`, `3`},
+		{`=INDEX(E1:E4,0)`, `{10;20;30;TRUE}`},
+		{`=INDEX(D1:F4,0,2)`, `{10;20;30;TRUE}`},
+		{`=INDEX(D1:F4,5,1)`, `#REF!`},
+		{`=MATCH("cherry",D1:D4,0)`, `3`},
+		{`=MATCH(25,E1:E3)`, `2`},
+		{`=MATCH(25,{30,20,10},-1)`, `1`},
+		{`=MATCH(5,E1:E3)`, `#N/A`},
+		{`=COUNTIF(F1:F4,"East")`, `3`},
+		{`=COUNTIF(E1:E4,">=20")`, `2`},
+		{`=COUNTIF(D1:D4,"a*")`, `2`},
+		{`=COUNTIF(D1:D4,"<>Apple")`, `3`},
+		{`=COUNTIF(A1:C2,"")`, `1`},
+		{`=COUNTIFS(F1:F4,"East",E1:E4,">10")`, `1`},
+		{`=SUMIF(F1:F4,"East",E1:E4)`, `40`},
+		{`=SUMIF(E1:E4,">15")`, `50`},
+		{`=SUMIFS(E1:E4,F1:F4,"East",D1:D4,"<>Apple")`, `30`},
+		{`=SUMIFS(E1:E4,F1:F3,"East")`, `#VALUE!`},
+		{`=LEFT("あいうえお",2)`, `あい`},
+		{`=LEFT("abc")`, `a`},
+		{`=RIGHT("abc",5)`, `abc`},
+		{`=RIGHT("abc",-1)`, `#VALUE!`},
+		{`=MID("abcdef",2,3)`, `bcd`},
+		{`=MID("abc",5,1)`, ``},
+		{`=MID("abc",0,1)`, `#VALUE!`},
+		{`=MID("abc",1,1E+300)`, `abc`},
+		{`=MID("abc",2,1E+19)`, `bc`},
+		{`=MID("abc",1E+300,1)`, ``},
+		{`=LEFT("abc",1E+300)`, `abc`},
+		{`=RIGHT("abc",1E+19)`, `abc`},
+		{`=LEN("あいう")`, `3`},
+		{`=LEN(A1)`, `1`},
+		{`=DATE(2024,2,29)`, `45351`},
+		{`=DATE(1900,3,1)`, `61`},
+		{`=DATE(1900,1,1)`, `1`},
+		{`=DATE(2023,14,1)`, `45323`},
+		{`=DATE(10000,1,1)`, `#NUM!`},
+		{`=DATE(2000,1E+300,1)`, `#NUM!`},
+		{`=DATE(2000,1,-1E+300)`, `#NUM!`},
+		{`=DATE(1E+300,1,1)`, `#NUM!`},
+		{`=DATE(9999,12,31)`, `2958465`},
+		{`=DATE(9999,13,1)`, `#NUM!`},
+		{`=ROUND(2.675,2)`, `2.68`},
+		{`=ROUND(-2.5,0)`, `-3`},
+		{`=ROUND(1234.5,-2)`, `1200`},
+		{`=ROUNDUP(1.001,1)`, `1.1`},
+		{`=ROUNDUP(-1.001,1)`, `-1.1`},
+		{`=ROUNDDOWN(1.999,2)`, `1.99`},
+		{`=ROUND(1.5,400)`, `1.5`},
+		{`=ROUND(1E+300,310)`, `1E+300`},
+		{`=ROUND(1.5,-400)`, `0`},
+		{`=ROUND(1.5,1E+300)`, `1.5`},
+		{`=ROUND(1.5,-1E+300)`, `0`},
+		{`=TEXT(1234.567,"#,##0.00")`, `1,234.57`},
+		{`=TEXT(0.256,"0.0%")`, `25.6%`},
+		{`=TEXT(5,"000")`, `005`},
+		{`=TEXT(-5,"0;(0)")`, `(5)`},
+		{`=TEXT(0.5,"#.00")`, `.50`},
+		{`=TEXT(12345,"0.00E+00")`, `1.23E+04`},
+		{`=TEXT(3,"""Total: ""0")`, `Total: 3`},
+		{`=TEXT(45351,"yyyy-mm-dd")`, `2024-02-29`},
+		{`=TEXT(45351.75,"dddd, mmmm d, yy h:mm AM/PM")`, `Thursday, February 29, 24 6:00 PM`},
+		{`=TEXT(0.5,"hh:mm:ss")`, `12:00:00`},
+		{`=TEXT("abc","0")`, `abc`},
+		{`=TEXT(1.5,"General")`, `1.5`},
+		{`=FOO()`, `#NAME?`},
+	} {
+		e := NewEvaluator("Sheet1", resolver)
+		v, err := e.EvaluateFormula(c.formula)
+		if err != nil {
+			t.Errorf("%s: %v", c.formula, err)
+			continue
+		}
+		if actual := v.String(); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+}
+
+func TestRegisterFunction(t *testing.T) {
+	RegisterFunction("test.upper", func(args []Value) Value {
+		if len(args) != 1 {
+			return ErrorValue(FormulaErrorVALUE)
+		}
+		return TextValue(strings.ToUpper(args[0].ToText().Text))
+	})
+	e := NewEvaluator("Sheet1", newTestResolver())
+	v, err := e.EvaluateFormula(`=TEST.UPPER("abc")`)
+	if err != nil || v.String() != "ABC" {
+		t.Errorf("expected ABC, got %s, %v", v, err)
+	}
+	e.Functions = map[string]Function{
+		"SUM": func(args []Value) Value { return NumberValue(42) },
+		"IF":  func(args []Value) Value { return NumberValue(float64(len(args))) },
+	}
+	if v, err = e.EvaluateFormula(`=SUM(1)`); err != nil || v.String() != "42" {
+		t.Errorf("expected 42, got %s, %v", v, err)
+	}
+	if v, err = e.EvaluateFormula(`=IF(TRUE,1,2)`); err != nil || v.String() != "3" {
+		t.Errorf("expected 3, got %s, %v", v, err)
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	for _, c := range []struct {
+		pattern, text string
+		expected      bool
+	}{
+		{"a*", "Apple", true},
+		{"*le", "apple", true},
+		{"a?p*e", "apple", true},
+		{"a*p*x", "apple", false},
+		{"*", "", true},
+		{"?", "", false},
+		{"~*", "*", true},
+		{"~*", "a", false},
+		{"a~?", "a?", true},
+		{"a~", "a~", true},
+		{"*あ*", "いあう", true},
+		{strings.Repeat("*a", 20) + "*b", strings.Repeat("a", 200), false},
+	} {
+		if actual := matchWildcard(c.pattern, c.text); actual != c.expected {
+			t.Errorf("%q %q: expected %t, got %t", c.pattern, c.text, c.expected, actual)
+		}
+	}
+}
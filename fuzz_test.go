@@ -4,7 +4,9 @@
 package efp_test
 
 import (
+	"reflect"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/xuri/efp"
 )
@@ -12,13 +14,28 @@ import (
 func FuzzParse(f *testing.F) {
 	f.Add("=0")
 	f.Add("=SUM(A3+B9*2)/2")
+	f.Add(" = SUM( 'My Sheet'!A1 , \"\x88\" ) ")
 	f.Fuzz(func(t *testing.T, formula string) {
 		p := efp.ExcelParser()
 		tokens := p.Parse(formula)
-		_ = tokens
+		if utf8.ValidString(formula) {
+			var streamed []efp.Token
+			lexer := efp.NewLexer(formula)
+			for token, ok := lexer.Next(); ok; token, ok = lexer.Next() {
+				streamed = append(streamed, token)
+			}
+			if !reflect.DeepEqual(tokens, streamed) {
+				t.Errorf("expected %+v, got %+v", tokens, streamed)
+			}
+		}
 		if p.InError {
 			t.Skip()
 		}
 		t.Log(p.Render())
+		lossless := efp.ExcelParser(efp.Options{Lossless: true})
+		lossless.Parse(formula)
+		if rendered := lossless.Render(); rendered != formula {
+			t.Errorf("expected %q, got %q", formula, rendered)
+		}
 	})
 }
@@ -0,0 +1,295 @@
+package efp
+
+import (
+	"sort"
+	"strings"
+)
+
+// CircularReferenceError directly maps a circular reference found in the
+// dependency graph. Cells are the cells of the cycle in dependency order,
+// the first cell is repeated at the end.
+type CircularReferenceError struct {
+	Cells []string
+}
+
+// Error returns the message of the circular reference with its cells.
+func (err CircularReferenceError) Error() string {
+	return "circular reference: " + strings.Join(err.Cells, " -> ")
+}
+
+// graphCell directly maps the location of a cell in the dependency graph,
+// the sheet name is in upper case.
+type graphCell struct {
+	sheet    string
+	col, row int
+}
+
+// graphArea directly maps a cell or range read by a formula.
+type graphArea struct {
+	sheet                  string
+	col1, row1, col2, row2 int
+}
+
+// contains returns whether the cell is inside the area.
+func (a graphArea) contains(c graphCell) bool {
+	return a.sheet == c.sheet && c.col >= a.col1 && c.col <= a.col2 && c.row >= a.row1 && c.row <= a.row2
+}
+
+// graphFormula directly maps a formula cell of the dependency graph. Name is
+// the cell reference with the sheet, such as 'My Sheet'!A1, areas are the
+// cells and ranges read by the formula.
+type graphFormula struct {
+	name  string
+	cell  graphCell
+	areas []graphArea
+}
+
+// DependencyGraph directly maps the dependencies between the formula cells
+// of a workbook. The edges are range aware, a formula reading a range
+// depends on every formula cell inside the range. Defined names, tables,
+// external workbooks and 3D references are not followed. The edges between
+// the formula cells are built on first use after the graph changes, so even
+// the read only methods update the graph, and a DependencyGraph must not be
+// used concurrently.
+type DependencyGraph struct {
+	formulas   map[graphCell]*graphFormula
+	edges      map[*graphFormula][]*graphFormula
+	dependents map[*graphFormula][]*graphFormula
+}
+
+// NewDependencyGraph provides function to create an empty dependency graph.
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{formulas: make(map[graphCell]*graphFormula)}
+}
+
+// graphCellOf returns the location of the cell in the sheet.
+func graphCellOf(sheet, cell string) (graphCell, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	return graphCell{sheet: strings.ToUpper(sheet), col: col, row: row}, err
+}
+
+// SetFormula provides function to set the formula of the cell in the sheet,
+// such as =SUM(A1:A3), an empty formula removes the cell from the graph.
+// Formulas with syntax errors return the first diagnostic as the error.
+func (g *DependencyGraph) SetFormula(sheet, cell, formula string) error {
+	key, err := graphCellOf(sheet, cell)
+	if err != nil {
+		return err
+	}
+	if formula == "" {
+		delete(g.formulas, key)
+		g.invalidate()
+		return nil
+	}
+	ps := ExcelParser()
+	tokens, diagnostics := ps.ParseWithErrors(formula)
+	if len(diagnostics) > 0 {
+		return diagnostics[0]
+	}
+	f := &graphFormula{
+		name: Reference{Sheet: sheet, Start: CellRef{Col: key.col, Row: key.row}}.String(),
+		cell: key,
+	}
+	for _, p := range Precedents(tokens) {
+		if p.Type != PrecedentCell && p.Type != PrecedentRange {
+			continue
+		}
+		ref, err := ParseReference(p.Text)
+		if err != nil || ref.Workbook != "" || ref.LastSheet != "" {
+			continue
+		}
+		area := graphArea{sheet: key.sheet}
+		if ref.Sheet != "" {
+			area.sheet = strings.ToUpper(ref.Sheet)
+		}
+		area.col1, area.row1, area.col2, area.row2 = ref.Bounds()
+		f.areas = append(f.areas, area)
+	}
+	g.formulas[key] = f
+	g.invalidate()
+	return nil
+}
+
+// invalidate provides function to drop the edges of the graph after it
+// changes.
+func (g *DependencyGraph) invalidate() {
+	g.edges, g.dependents = nil, nil
+}
+
+// index returns the formula cells read by each formula cell and the formula
+// cells reading each formula cell, which are built once until the graph
+// changes.
+func (g *DependencyGraph) index() (map[*graphFormula][]*graphFormula, map[*graphFormula][]*graphFormula) {
+	if g.edges == nil {
+		sheets := g.sheetFormulas()
+		g.edges = make(map[*graphFormula][]*graphFormula, len(g.formulas))
+		g.dependents = make(map[*graphFormula][]*graphFormula)
+		for _, f := range g.formulas {
+			precedents := g.precedents(f, sheets)
+			g.edges[f] = precedents
+			for _, p := range precedents {
+				g.dependents[p] = append(g.dependents[p], f)
+			}
+		}
+	}
+	return g.edges, g.dependents
+}
+
+// sheetFormulas returns the formula cells of the graph grouped by sheet.
+func (g *DependencyGraph) sheetFormulas() map[string][]*graphFormula {
+	sheets := make(map[string][]*graphFormula)
+	for _, f := range g.formulas {
+		sheets[f.cell.sheet] = append(sheets[f.cell.sheet], f)
+	}
+	return sheets
+}
+
+// precedents returns the formula cells read by the formula, small areas are
+// looked up cell by cell and large areas are matched against the formula
+// cells of the sheet.
+func (g *DependencyGraph) precedents(f *graphFormula, sheets map[string][]*graphFormula) []*graphFormula {
+	var (
+		result []*graphFormula
+		seen   = map[*graphFormula]bool{}
+	)
+	add := func(p *graphFormula) {
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	for _, area := range f.areas {
+		formulas := sheets[area.sheet]
+		if size := (area.col2 - area.col1 + 1) * (area.row2 - area.row1 + 1); size < len(formulas) {
+			for col := area.col1; col <= area.col2; col++ {
+				for row := area.row1; row <= area.row2; row++ {
+					if p, ok := g.formulas[graphCell{sheet: area.sheet, col: col, row: row}]; ok {
+						add(p)
+					}
+				}
+			}
+			continue
+		}
+		for _, p := range formulas {
+			if area.contains(p.cell) {
+				add(p)
+			}
+		}
+	}
+	sortFormulas(result)
+	return result
+}
+
+// sortFormulas provides function to sort the formula cells by sheet, row and
+// column for a stable recalculation order.
+func sortFormulas(formulas []*graphFormula) {
+	sort.Slice(formulas, func(i, j int) bool {
+		a, b := formulas[i].cell, formulas[j].cell
+		if a.sheet != b.sheet {
+			return a.sheet < b.sheet
+		}
+		if a.row != b.row {
+			return a.row < b.row
+		}
+		return a.col < b.col
+	})
+}
+
+// circularReference returns the error of the cycle closed by the formula
+// cell on the path of the depth first search.
+func circularReference(path []*graphFormula, f *graphFormula) CircularReferenceError {
+	i := len(path) - 1
+	for path[i] != f {
+		i--
+	}
+	cells := make([]string, 0, len(path)-i+1)
+	for _, p := range path[i:] {
+		cells = append(cells, p.name)
+	}
+	return CircularReferenceError{Cells: append(cells, f.name)}
+}
+
+// order returns the names of the formula cells in the given set, or all
+// formula cells if the set is nil, sorted so that each cell comes after the
+// cells it reads. The depth first search keeps its path on the heap, so that
+// long dependency chains can't overflow the stack.
+func (g *DependencyGraph) order(within map[*graphFormula]bool) ([]string, error) {
+	var (
+		edges, _ = g.index()
+		formulas []*graphFormula
+		state    = map[*graphFormula]int{}
+		result   []string
+	)
+	for _, f := range g.formulas {
+		if within == nil || within[f] {
+			formulas = append(formulas, f)
+		}
+	}
+	sortFormulas(formulas)
+	for _, root := range formulas {
+		if state[root] != 0 {
+			continue
+		}
+		state[root] = 1
+		path, next := []*graphFormula{root}, []int{0}
+		for len(path) > 0 {
+			top := len(path) - 1
+			f := path[top]
+			if next[top] == len(edges[f]) {
+				state[f], path, next = 2, path[:top], next[:top]
+				result = append(result, f.name)
+				continue
+			}
+			p := edges[f][next[top]]
+			if next[top]++; within != nil && !within[p] {
+				continue
+			}
+			switch state[p] {
+			case 1:
+				return nil, circularReference(path, p)
+			case 0:
+				state[p], path, next = 1, append(path, p), append(next, 0)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Order provides function to get the recalculation order of all formula
+// cells, each cell comes after the cells it reads. A CircularReferenceError
+// is returned when the formulas depend on themselves.
+func (g *DependencyGraph) Order() ([]string, error) {
+	return g.order(nil)
+}
+
+// Dirty provides function to get the formula cells which must be recalculated
+// when the value of the cell in the sheet changes, in recalculation order.
+func (g *DependencyGraph) Dirty(sheet, cell string) ([]string, error) {
+	key, err := graphCellOf(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	_, dependents := g.index()
+	var queue []*graphFormula
+	for _, f := range g.formulas {
+		for _, area := range f.areas {
+			if area.contains(key) {
+				queue = append(queue, f)
+				break
+			}
+		}
+	}
+	dirty := make(map[*graphFormula]bool)
+	for len(queue) > 0 {
+		f := queue[0]
+		if queue = queue[1:]; dirty[f] {
+			continue
+		}
+		dirty[f] = true
+		queue = append(queue, dependents[f]...)
+	}
+	if len(dirty) == 0 {
+		return nil, nil
+	}
+	return g.order(dirty)
+}
@@ -0,0 +1,111 @@
+package efp
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	g := NewDependencyGraph()
+	for _, c := range [][3]string{
+		{"Sheet1", "C1", "=SUM(A1:B2)+C2"},
+		{"Sheet1", "C2", "=A1*2"},
+		{"Sheet1", "D1", "=C1+'My Sheet'!A1"},
+		{"My Sheet", "A1", "=Sheet1!B1+1"},
+		{"Sheet1", "E1", "=SUM(C:C)"},
+		{"Sheet1", "F1", "=Name+[Book1.xlsx]Sheet1!A1"},
+	} {
+		if err := g.SetFormula(c[0], c[1], c[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	order, err := g.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{`'My Sheet'!A1`, "Sheet1!C2", "Sheet1!C1", "Sheet1!D1", "Sheet1!E1", "Sheet1!F1"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected %v, got %v", expected, order)
+	}
+	for _, c := range []struct {
+		sheet, cell string
+		expected    []string
+	}{
+		{"Sheet1", "A1", []string{"Sheet1!C2", "Sheet1!C1", "Sheet1!D1", "Sheet1!E1"}},
+		{"sheet1", "B1", []string{`'My Sheet'!A1`, "Sheet1!C1", "Sheet1!D1", "Sheet1!E1"}},
+		{"Sheet1", "C1", []string{"Sheet1!D1", "Sheet1!E1"}},
+		{"Sheet1", "Z9", nil},
+	} {
+		dirty, err := g.Dirty(c.sheet, c.cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(dirty, c.expected) {
+			t.Errorf("%s!%s: expected %v, got %v", c.sheet, c.cell, c.expected, dirty)
+		}
+	}
+
+	if err := g.SetFormula("Sheet1", "A1", "=E1"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = g.Order()
+	if cycle, ok := err.(CircularReferenceError); !ok || !reflect.DeepEqual(cycle.Cells,
+		[]string{"Sheet1!A1", "Sheet1!E1", "Sheet1!C1", "Sheet1!A1"}) {
+		t.Errorf("expected circular reference, got %v", err)
+	}
+	if _, err = g.Dirty("Sheet1", "C2"); err == nil {
+		t.Error("expected error")
+	}
+	if err := g.SetFormula("Sheet1", "A1", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.Order(); err != nil {
+		t.Error(err)
+	}
+	if err := g.SetFormula("Sheet1", "G1", "=G1:G2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.Order(); err == nil || err.Error() != "circular reference: Sheet1!G1 -> Sheet1!G1" {
+		t.Errorf("expected circular reference, got %v", err)
+	}
+	if err := g.SetFormula("Sheet1", "A0", "=1"); err == nil {
+		t.Error("expected error")
+	}
+	if err := g.SetFormula("Sheet1", "A1", "=SUM(1"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := g.Dirty("Sheet1", "1"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDependencyGraphChain(t *testing.T) {
+	g := NewDependencyGraph()
+	const length = 20000
+	for row := 1; row < length; row++ {
+		if err := g.SetFormula("Sheet1", "A"+strconv.Itoa(row), "=A"+strconv.Itoa(row+1)+"+1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	order, err := g.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != length-1 || order[0] != "Sheet1!A"+strconv.Itoa(length-1) || order[len(order)-1] != "Sheet1!A1" {
+		t.Errorf("unexpected order of %d cells", len(order))
+	}
+	for i := 0; i < 3; i++ {
+		if dirty, err := g.Dirty("Sheet1", "A"+strconv.Itoa(length)); err != nil || len(dirty) != length-1 {
+			t.Errorf("expected %d dirty cells, got %d, %v", length-1, len(dirty), err)
+		}
+	}
+	if err := g.SetFormula("Sheet1", "A"+strconv.Itoa(length), "=A1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Order(); err == nil {
+		t.Error("expected circular reference")
+	} else if cycle := err.(CircularReferenceError); len(cycle.Cells) != length+1 {
+		t.Errorf("expected %d cells, got %d", length+1, len(cycle.Cells))
+	}
+}
@@ -0,0 +1,461 @@
+package efp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lexer states for the characters which don't end a token.
+const (
+	lexOperand = iota
+	lexString
+	lexPath
+	lexRange
+	lexError
+)
+
+// Lexer stack item kinds.
+const (
+	lexFunction byte = iota
+	lexSubexpression
+	lexArray
+	lexArrayRow
+)
+
+// lexQueueSize is the capacity of the lexer queue, large enough for the
+// tokens of a single character and the token after a white-space.
+const lexQueueSize = 8
+
+// lexPos directly maps a location in the formula, b is the byte offset and r
+// is the rune offset.
+type lexPos struct {
+	b, r int
+}
+
+// Lexer directly maps a streaming tokenizer of a formula, which yields the
+// same tokens as Parse one at a time without building token lists. Token
+// values are sub-strings of the formula except for the texts with embedded
+// quotes and the localized decimal separators, so a Lexer reused by Reset
+// doesn't allocate memory for typical formulas. No diagnostics are recorded,
+// the syntax errors are tokenized as Parse does. A Lexer must not be copied
+// after first use.
+type Lexer struct {
+	ps      Parser
+	locale  Locale
+	formula string
+	end     int
+	pos     lexPos
+	start   lexPos
+	acc     bool
+	decimal int
+	state   int
+	escaped bool
+	depth   int
+	done    bool
+	stack   []byte
+	frames  [16]byte
+	queue   [lexQueueSize]Token
+	head    int
+	size    int
+	prevRaw Token
+	prev    Token
+	started bool
+}
+
+// NewLexer provides function to create a streaming tokenizer of the formula
+// with the optional parser options.
+func NewLexer(formula string, opts ...Options) *Lexer {
+	l := &Lexer{ps: ExcelParser(opts...)}
+	l.locale = l.ps.options.Locale.normalize()
+	l.Reset(formula)
+	return l
+}
+
+// Reset provides function to start tokenizing another formula with the same
+// options, reusing the memory of the lexer.
+func (l *Lexer) Reset(formula string) {
+	trimmed := strings.TrimLeftFunc(formula, unicode.IsSpace)
+	l.pos = lexPos{b: len(formula) - len(trimmed), r: utf8.RuneCountInString(formula[:len(formula)-len(trimmed)])}
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	l.formula, l.end = formula, l.pos.b+len(trimmed)
+	if trimmed != "" && trimmed[0] == '=' {
+		l.pos.b, l.pos.r = l.pos.b+1, l.pos.r+1
+	}
+	l.start, l.acc, l.decimal, l.state, l.escaped, l.depth = l.pos, false, -1, lexOperand, false, 0
+	l.done, l.stack, l.head, l.size, l.started = false, l.frames[:0], 0, 0, false
+	// the formula starts with an "=" infix operator which is not a token
+	l.prevRaw = fToken("=", TokenTypeOperatorInfix, "")
+	l.prev = Token{}
+}
+
+// Next provides function to get the next token of the formula, and returns
+// false at the end of the formula.
+func (l *Lexer) Next() (Token, bool) {
+	for {
+		t, ok := l.raw()
+		if !ok {
+			return Token{}, false
+		}
+		prev := l.prevRaw
+		l.prevRaw = t
+		if t.TType == TokenTypeWhitespace {
+			next, ok := l.peek()
+			if !ok || !isIntersectionLeft(prev) || !isIntersectionRight(next) {
+				continue
+			}
+			t = Token{TType: TokenTypeOperatorInfix, TSubType: TokenSubTypeIntersection, Span: t.Span}
+		}
+		l.classify(&t)
+		l.prev, l.started = t, true
+		if t.TType != TokenTypeNoop {
+			return t, true
+		}
+	}
+}
+
+// isIntersectionLeft returns whether the token before a white-space ends an
+// operand of the intersection operator.
+func isIntersectionLeft(t Token) bool {
+	return (t.TType == TokenTypeFunction || t.TType == TokenTypeSubexpression) && t.TSubType == TokenSubTypeStop || t.TType == TokenTypeOperand
+}
+
+// isIntersectionRight returns whether the token after a white-space starts an
+// operand of the intersection operator.
+func isIntersectionRight(t Token) bool {
+	return (t.TType == TokenTypeFunction || t.TType == TokenTypeSubexpression) && t.TSubType == TokenSubTypeStart || t.TType == TokenTypeOperand
+}
+
+// classify provides function to switch the infix "-" and "+" operators to
+// prefix and no-op operators when appropriate, identify the operand and
+// infix operator subtypes and pull "@" from in front of function names.
+func (l *Lexer) classify(t *Token) {
+	switch t.TType {
+	case TokenTypeOperatorInfix:
+		if t.TValue == "-" || t.TValue == "+" {
+			p := l.prev
+			if l.started && (isIntersectionLeft(p) || p.TType == TokenTypeOperatorPostfix) {
+				t.TSubType = TokenSubTypeMath
+			} else if t.TValue == "-" {
+				t.TType = TokenTypeOperatorPrefix
+			} else {
+				t.TType = TokenTypeNoop
+			}
+			return
+		}
+		if t.TSubType == "" {
+			switch t.TValue[0] {
+			case '<', '>', '=':
+				t.TSubType = TokenSubTypeLogical
+			case '&':
+				t.TSubType = TokenSubTypeConcatenation
+			default:
+				t.TSubType = TokenSubTypeMath
+			}
+		}
+	case TokenTypeOperand:
+		if t.TSubType == "" {
+			t.TSubType = l.ps.classifyOperand(t.TValue)
+		}
+	case TokenTypeFunction:
+		if len(t.TValue) > 0 && t.TValue[0] == '@' {
+			t.TValue = t.TValue[1:]
+		}
+	}
+}
+
+// raw provides function to get the next token before the white-space,
+// operator and operand processing.
+func (l *Lexer) raw() (Token, bool) {
+	if _, ok := l.peek(); !ok {
+		return Token{}, false
+	}
+	t := l.queue[l.head]
+	l.head, l.size = (l.head+1)%lexQueueSize, l.size-1
+	return t, true
+}
+
+// peek provides function to get the next raw token without consuming it.
+func (l *Lexer) peek() (Token, bool) {
+	for l.size == 0 && !l.done {
+		l.scan()
+	}
+	if l.size == 0 {
+		return Token{}, false
+	}
+	return l.queue[l.head], true
+}
+
+// emit provides function to add a raw token located between the given
+// positions to the queue.
+func (l *Lexer) emit(value, tokenType, subType string, start, end lexPos) {
+	t := fToken(value, tokenType, subType)
+	t.Span = Span{Start: start.r, End: end.r, ByteStart: start.b, ByteEnd: end.b}
+	l.queue[(l.head+l.size)%lexQueueSize] = t
+	l.size++
+}
+
+// value returns the value of the accumulated token, with the localized
+// decimal separator converted to ".".
+func (l *Lexer) value() string {
+	if l.decimal == -1 {
+		return l.formula[l.start.b:l.pos.b]
+	}
+	_, size := utf8.DecodeRuneInString(l.formula[l.decimal:])
+	return l.formula[l.start.b:l.decimal] + "." + l.formula[l.decimal+size:l.pos.b]
+}
+
+// flush provides function to add the accumulated token to the queue with
+// the given type.
+func (l *Lexer) flush(tokenType string) {
+	if l.acc {
+		l.emit(l.value(), tokenType, "", l.start, l.pos)
+		l.acc, l.decimal = false, -1
+	}
+}
+
+// push provides function to add a start token to the queue and push its
+// kind onto the stack.
+func (l *Lexer) push(value, tokenType string, kind byte, start, end lexPos) {
+	l.emit(value, tokenType, TokenSubTypeStart, start, end)
+	l.stack = append(l.stack, kind)
+}
+
+// stop provides function to pop a kind off the stack and add the
+// corresponding stop token to the queue.
+func (l *Lexer) stop(start, end lexPos) {
+	tokenType := TokenTypeFunction
+	if n := len(l.stack); n > 0 {
+		if l.stack[n-1] == lexSubexpression {
+			tokenType = TokenTypeSubexpression
+		}
+		l.stack = l.stack[:n-1]
+	}
+	l.emit("", tokenType, TokenSubTypeStop, start, end)
+}
+
+// char returns the character at the byte offset and its size, or zero at the
+// end of the formula.
+func (l *Lexer) char(b int) (rune, int) {
+	if b >= l.end {
+		return 0, 0
+	}
+	if c := l.formula[b]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(l.formula[b:l.end])
+}
+
+// after returns the position after the character of the given size.
+func (p lexPos) after(size int) lexPos {
+	return lexPos{b: p.b + size, r: p.r + 1}
+}
+
+// isExponent returns whether the accumulated token is the mantissa of a
+// number in scientific notation followed by "E", such as 1.5E.
+func (l *Lexer) isExponent() bool {
+	token := l.formula[l.start.b:l.pos.b]
+	if len(token) < 2 || token[0] < '1' || token[0] > '9' || token[len(token)-1] != 'E' {
+		return false
+	}
+	fraction := token[1 : len(token)-1]
+	if fraction == "" {
+		return true
+	}
+	if l.decimal == l.start.b+1 {
+		_, size := utf8.DecodeRuneInString(fraction)
+		fraction = fraction[size:]
+	} else if fraction[0] == '.' {
+		fraction = fraction[1:]
+	} else {
+		return false
+	}
+	return isDigitString(fraction)
+}
+
+// isDigitString returns whether the text is a non-empty sequence of decimal
+// digits.
+func isDigitString(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return false
+		}
+	}
+	return text != ""
+}
+
+// isErrorValue returns whether the text is a formula error value, in English
+// or in any of the translation languages.
+func isErrorValue(text string) bool {
+	switch text {
+	case FormulaErrorNULL, FormulaErrorDIV, FormulaErrorVALUE, FormulaErrorREF, FormulaErrorNAME,
+		FormulaErrorNUM, FormulaErrorNA, FormulaErrorSPILL, FormulaErrorCALC, FormulaErrorGETTINGDATA:
+		return true
+	}
+	return localizedErrors[text]
+}
+
+// finish provides function to add the token left at the end of the formula
+// to the queue.
+func (l *Lexer) finish() {
+	l.done = true
+	if l.state == lexString {
+		if content := l.formula[l.start.b+1 : l.pos.b]; content != "" {
+			l.emit(l.text(content), TokenTypeOperand, "", l.start, l.pos)
+		}
+		return
+	}
+	l.flush(TokenTypeOperand)
+}
+
+// text returns the content of a double-quoted string with the embedded
+// quotes unescaped.
+func (l *Lexer) text(content string) string {
+	if l.escaped {
+		return strings.Replace(content, `""`, `"`, -1)
+	}
+	return content
+}
+
+// scan provides function to evaluate the characters at the current position
+// and add the completed tokens to the queue. It mirrors the state machine of
+// Parser.getTokens, which must be kept in sync with it.
+func (l *Lexer) scan() {
+	c, size := l.char(l.pos.b)
+	if size == 0 {
+		l.finish()
+		return
+	}
+	next := l.pos.after(size)
+	switch l.state {
+	case lexString:
+		// double-quoted strings, embeds are doubled, end marks token
+		if c == QuoteDouble {
+			if n, _ := l.char(next.b); n == QuoteDouble {
+				l.escaped, l.pos = true, next.after(1)
+				return
+			}
+			l.emit(l.text(l.formula[l.start.b+1:l.pos.b]), TokenTypeOperand, TokenSubTypeText, l.start, next)
+			l.state = lexOperand
+		}
+		l.pos = next
+		return
+	case lexPath:
+		// single-quoted strings, embeds are doubled, end does not mark a token
+		if c == QuoteSingle {
+			if n, _ := l.char(next.b); n == QuoteSingle {
+				next = next.after(1)
+			} else {
+				l.state = lexOperand
+			}
+		}
+		l.pos = next
+		return
+	case lexRange:
+		// bracketed strings, nested brackets are counted, embeds are escaped
+		// by single quotes, end does not mark a token
+		switch c {
+		case QuoteSingle:
+			if _, n := l.char(next.b); n > 0 {
+				next = next.after(n)
+			}
+		case BracketOpen:
+			l.depth++
+		case BracketClose:
+			if l.depth--; l.depth == 0 {
+				l.state = lexOperand
+			}
+		}
+		l.pos = next
+		return
+	case lexError:
+		// error values, end marks a token
+		if l.pos = next; isErrorValue(l.formula[l.start.b:l.pos.b]) {
+			l.emit(l.formula[l.start.b:l.pos.b], TokenTypeOperand, TokenSubTypeError, l.start, l.pos)
+			l.state, l.acc = lexOperand, false
+		}
+		return
+	}
+
+	// scientific notation check
+	if l.acc && (c == '+' || c == '-') && l.isExponent() {
+		l.pos = next
+		return
+	}
+
+	inArray := len(l.stack) > 0 && l.stack[len(l.stack)-1] == lexArrayRow
+	n, _ := l.char(next.b)
+	switch {
+	case c == QuoteDouble:
+		l.flush(TokenTypeUnknown)
+		l.state, l.escaped, l.start = lexString, false, l.pos
+	case c == QuoteSingle:
+		l.flush(TokenTypeUnknown)
+		l.state, l.start, l.acc = lexPath, l.pos, true
+	case c == BracketOpen:
+		if !l.acc {
+			l.start, l.acc = l.pos, true
+		}
+		l.state, l.depth = lexRange, 1
+	case c == ErrorStart:
+		l.flush(TokenTypeUnknown)
+		l.state, l.start, l.acc = lexError, l.pos, true
+	case c == BraceOpen:
+		l.flush(TokenTypeUnknown)
+		l.push("ARRAY", TokenTypeFunction, lexArray, l.pos, next)
+		l.push("ARRAYROW", TokenTypeFunction, lexArrayRow, next, next)
+	case c == l.locale.ArrayRowSeparator && (inArray || l.locale.ArrayRowSeparator != l.locale.ArgumentSeparator):
+		l.flush(TokenTypeOperand)
+		l.stop(l.pos, l.pos)
+		l.emit(string(Comma), TokenTypeArgument, "", l.pos, next)
+		l.push("ARRAYROW", TokenTypeFunction, lexArrayRow, next, next)
+	case c == BraceClose:
+		l.flush(TokenTypeOperand)
+		l.stop(l.pos, l.pos)
+		l.stop(l.pos, next)
+	case c == Whitespace:
+		l.flush(TokenTypeOperand)
+		start := l.pos
+		for l.pos = next; n == Whitespace; n, _ = l.char(l.pos.b) {
+			l.pos = l.pos.after(1)
+		}
+		l.emit("", TokenTypeWhitespace, "", start, l.pos)
+		return
+	case (c == '<' || c == '>') && (n == '=' || (c == '<' && n == '>')):
+		l.flush(TokenTypeOperand)
+		next = next.after(1)
+		l.emit(l.formula[l.pos.b:next.b], TokenTypeOperatorInfix, TokenSubTypeLogical, l.pos, next)
+	case isInfix(c):
+		l.flush(TokenTypeOperand)
+		l.emit(l.formula[l.pos.b:next.b], TokenTypeOperatorInfix, "", l.pos, next)
+	case c == OperatorsPostfix:
+		l.flush(TokenTypeOperand)
+		l.emit(l.formula[l.pos.b:next.b], TokenTypeOperatorPostfix, "", l.pos, next)
+	case c == ParenOpen:
+		if l.acc {
+			l.push(l.value(), TokenTypeFunction, lexFunction, l.start, next)
+			l.acc, l.decimal = false, -1
+		} else {
+			l.push("", TokenTypeSubexpression, lexSubexpression, l.pos, next)
+		}
+	case c == l.locale.ArgumentSeparator || (inArray && c == l.locale.ArrayColumnSeparator):
+		l.flush(TokenTypeOperand)
+		if len(l.stack) == 0 || l.stack[len(l.stack)-1] == lexSubexpression {
+			l.emit(string(Comma), TokenTypeOperatorInfix, TokenSubTypeUnion, l.pos, next)
+		} else {
+			l.emit(string(Comma), TokenTypeArgument, "", l.pos, next)
+		}
+	case c == ParenClose:
+		l.flush(TokenTypeOperand)
+		l.stop(l.pos, next)
+	default:
+		// token accumulation, localized decimal separators are converted to "."
+		if !l.acc {
+			l.start, l.acc = l.pos, true
+		}
+		if c == l.locale.DecimalSeparator && c != '.' && l.decimal == -1 && isDigitString(l.formula[l.start.b:l.pos.b]) {
+			l.decimal = l.pos.b
+		}
+	}
+	l.pos = next
+}
@@ -0,0 +1,82 @@
+package efp
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// lexerFormulas is the formulas for comparing the lexer with the parser.
+var lexerFormulas = []string{
+	``, `=`, `  `, ` = 1 + 2 `, `1E+5`, `=1.5E-3+A1`, `=1,5E-3+1,5`,
+	`=SUM(B5:B15 A7:D7)+SUM((A:A,1:1))`, `='a''b'!A1 B2`, `="a""b"&"あい"`,
+	`="ab`, `='ab`, `=[ab`, `=#BAD`, `=#REF!+#N/A`, `={1,2;3,4}`, `={1.5;2,5}`,
+	`=SUM(1;2,5)`, `=+-1`, `=@SUM(1)`, `=A1 (B1)`, `=(A1) B1`, `=50%-1`,
+	`=1--1`, `=Table1[[#All],[C'#]]`, `=[Book.xlsx]S!A1`, `=INF+nan+0x1p3`,
+	`=A1:B2 C1`, `=)`, `=}`, `=;`, `=1;2`, `=a"b"`, `=é+1`, `=R[-1]C2+Tax`,
+	`=IF(A1<>"",IF(A1>=1,"a",),FALSE)`, `=_xlfn.XLOOKUP(A1,B:B,C:C)`,
+	`=#WERT!+#NV`, `=#¡DIV/0!&#ССЫЛКА!`, `=#NUL!+#NULL!`,
+}
+
+func TestLexer(t *testing.T) {
+	for _, opts := range []Options{{}, {Locale: LocaleEuropean}, {R1C1: true}, {DefinedNames: []string{"Tax"}}} {
+		lexer := NewLexer("", opts)
+		for _, formula := range lexerFormulas {
+			p := ExcelParser(opts)
+			expected := p.Parse(formula)
+			var actual []Token
+			lexer.Reset(formula)
+			for token, ok := lexer.Next(); ok; token, ok = lexer.Next() {
+				actual = append(actual, token)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: expected %+v, got %+v", formula, expected, actual)
+			}
+		}
+	}
+	if _, ok := NewLexer(`=1`).Next(); !ok {
+		t.Error("expected token")
+	}
+}
+
+func TestLexerAllocs(t *testing.T) {
+	formula := `=IF(SUM(Sheet1!$A$1:$B$10,C1)>=100,ROUND(AVERAGE(D1:D5)*1.5%,2),"none")`
+	lexer := NewLexer(formula)
+	allocs := testing.AllocsPerRun(100, func() {
+		lexer.Reset(formula)
+		for _, ok := lexer.Next(); ok; _, ok = lexer.Next() {
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestIsNumber(t *testing.T) {
+	for _, value := range []string{"1", "1.5", ".5", "1E+5", "1e5", "0x1p3", "0x_1p3", "1_000", "1__0", "INF", "Infinity", "nan", "in", "E1", "A1", "1:1", "1E", "0x", "1e999", ""} {
+		_, err := strconv.ParseFloat(value, 64)
+		if isNumber(value) != (err == nil) {
+			t.Errorf("%q: expected %t", value, err == nil)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	formula := `=IF(SUM(Sheet1!$A$1:$B$10,C1)>=100,ROUND(AVERAGE(D1:D5)*1.5%,2),"none")`
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := ExcelParser()
+		p.Parse(formula)
+	}
+}
+
+func BenchmarkLexer(b *testing.B) {
+	formula := `=IF(SUM(Sheet1!$A$1:$B$10,C1)>=100,ROUND(AVERAGE(D1:D5)*1.5%,2),"none")`
+	lexer := NewLexer(formula)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		lexer.Reset(formula)
+		for _, ok := lexer.Next(); ok; _, ok = lexer.Next() {
+		}
+	}
+}
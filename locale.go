@@ -0,0 +1,68 @@
+package efp
+
+// Locale directly maps the list and decimal separators used to write formulas
+// in the user interface of a language, such as =ROUND(1,5;0) for German. The
+// zero value of each separator means the English default.
+type Locale struct {
+	ArgumentSeparator    rune
+	DecimalSeparator     rune
+	ArrayColumnSeparator rune
+	ArrayRowSeparator    rune
+}
+
+// Predefined locales.
+var (
+	// LocaleEnglish is the locale used by Excel files and by English Excel,
+	// such as =ROUND(1.5,0) and {1,2;3,4}.
+	LocaleEnglish = Locale{
+		ArgumentSeparator:    Comma,
+		DecimalSeparator:     '.',
+		ArrayColumnSeparator: Comma,
+		ArrayRowSeparator:    Semicolon,
+	}
+	// LocaleEuropean is the locale used by most continental European
+	// languages, such as German, French, Spanish and Italian, which write
+	// =ROUND(1,5;0) and {1.2;3.4}.
+	LocaleEuropean = Locale{
+		ArgumentSeparator:    Semicolon,
+		DecimalSeparator:     Comma,
+		ArrayColumnSeparator: '.',
+		ArrayRowSeparator:    Semicolon,
+	}
+)
+
+// normalize returns the locale with the English default for each separator
+// which is not specified.
+func (l Locale) normalize() Locale {
+	if l.ArgumentSeparator == 0 {
+		l.ArgumentSeparator = LocaleEnglish.ArgumentSeparator
+	}
+	if l.DecimalSeparator == 0 {
+		l.DecimalSeparator = LocaleEnglish.DecimalSeparator
+	}
+	if l.ArrayColumnSeparator == 0 {
+		l.ArrayColumnSeparator = LocaleEnglish.ArrayColumnSeparator
+	}
+	if l.ArrayRowSeparator == 0 {
+		l.ArrayRowSeparator = LocaleEnglish.ArrayRowSeparator
+	}
+	return l
+}
+
+// RenderLocale provides function to get formatted formula after parsed, with
+// the separators of the locale, such as ROUND(1,5;0) for LocaleEuropean. The
+// separators not specified in the locale are the English ones.
+func (ps *Parser) RenderLocale(locale Locale) string {
+	return renderTokens(ps.Tokens.Items, locale.normalize())
+}
+
+// isDigits returns whether the accumulated token is a non-empty sequence of
+// decimal digits.
+func isDigits(token []rune) bool {
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(token) > 0
+}
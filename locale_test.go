@@ -0,0 +1,43 @@
+package efp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocale(t *testing.T) {
+	for _, c := range []struct {
+		formula, rendered, localized string
+		locale                       Locale
+		values                       []string
+	}{
+		{`=ROUND(1,5;0)`, `ROUND(1.5,0)`, `ROUND(1,5;0)`, LocaleEuropean, []string{`ROUND`, `1.5`, `,`, `0`, ``}},
+		{`=SUM({1,5.2;3.4,25E-1})`, `SUM({1.5,2;3,4.25E-1})`, `SUM({1,5.2;3.4,25E-1})`, LocaleEuropean,
+			[]string{`SUM`, `ARRAY`, `ARRAYROW`, `1.5`, `,`, `2`, ``, `,`, `ARRAYROW`, `3`, `,`, `4.25E-1`, ``, ``, ``}},
+		{`=SUM((A1;B1);"a;b")`, `SUM((A1,B1),"a;b")`, `SUM((A1;B1);"a;b")`, LocaleEuropean,
+			[]string{`SUM`, ``, `A1`, `,`, `B1`, ``, `,`, `a;b`, ``}},
+		{`=IF(A1,{1,2;3,4},0.5)`, `IF(A1,{1,2;3,4},0.5)`, `IF(A1,{1,2;3,4},0.5)`, Locale{},
+			[]string{`IF`, `A1`, `,`, `ARRAY`, `ARRAYROW`, `1`, `,`, `2`, ``, `,`, `ARRAYROW`, `3`, `,`, `4`, ``, ``, `,`, `0.5`, ``}},
+		{`={1\2@3\4}`, `{1,2;3,4}`, `{1\2@3\4}`, Locale{ArrayColumnSeparator: '\\', ArrayRowSeparator: '@'},
+			[]string{`ARRAY`, `ARRAYROW`, `1`, `,`, `2`, ``, `,`, `ARRAYROW`, `3`, `,`, `4`, ``, ``}},
+	} {
+		p := ExcelParser(Options{Locale: c.locale})
+		tokens, diagnostics := p.ParseWithErrors(c.formula)
+		if len(diagnostics) > 0 {
+			t.Errorf("%s: unexpected diagnostics %v", c.formula, diagnostics)
+		}
+		var values []string
+		for _, token := range tokens {
+			values = append(values, token.TValue)
+		}
+		if !reflect.DeepEqual(values, c.values) {
+			t.Errorf("%s: expected %q, got %q", c.formula, c.values, values)
+		}
+		if actual := p.Render(); actual != c.rendered {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.rendered, actual)
+		}
+		if actual := p.RenderLocale(c.locale); actual != c.localized {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.localized, actual)
+		}
+	}
+}
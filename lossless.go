@@ -0,0 +1,50 @@
+package efp
+
+import "strings"
+
+// renderLossless provides function to get the formula text after parsed,
+// which reproduces the original text of the unchanged tokens and the
+// characters between them, such as the leading "=", whitespace, no-op "+"
+// operators and the "@" in front of function names. The changed and inserted
+// tokens are rendered with the separators of the parser locale, and the text
+// of the removed tokens is dropped.
+func (ps *Parser) renderLossless() string {
+	covered := make([]bool, len(ps.Formula))
+	original := make(map[[2]int]Token, len(ps.original))
+	for _, t := range ps.original {
+		for i := t.Span.ByteStart; i < t.Span.ByteEnd; i++ {
+			covered[i] = true
+		}
+		original[[2]int{t.Span.ByteStart, t.Span.ByteEnd}] = t
+	}
+	var (
+		output strings.Builder
+		pos    int
+	)
+	// gap writes the characters of the original formula up to the offset,
+	// which don't belong to any token
+	gap := func(end int) {
+		for ; pos < end; pos++ {
+			if !covered[pos] {
+				output.WriteByte(ps.Formula[pos])
+			}
+		}
+	}
+	r := renderer{locale: ps.options.Locale.normalize()}
+	for _, t := range ps.Tokens.Items {
+		text := r.token(t)
+		o, ok := original[[2]int{t.Span.ByteStart, t.Span.ByteEnd}]
+		if !ok || t.Span.ByteStart < pos {
+			output.WriteString(text)
+			continue
+		}
+		gap(t.Span.ByteStart)
+		if o.TValue == t.TValue && o.TType == t.TType && o.TSubType == t.TSubType {
+			text = ps.Formula[t.Span.ByteStart:t.Span.ByteEnd]
+		}
+		output.WriteString(text)
+		pos = t.Span.ByteEnd
+	}
+	gap(len(ps.Formula))
+	return output.String()
+}
@@ -0,0 +1,57 @@
+package efp
+
+import "testing"
+
+func TestLosslessRender(t *testing.T) {
+	for _, formula := range []string{
+		` = SUM( A1 , 'My Sheet'!B2 )  `,
+		`=+1+@INDEX(A:A, 1)`,
+		`=IF(A1="say ""hi""",{1, 2; 3,4},  -2%)`,
+		`=Table1[[#Headers],[Region]:[Qty]] A1:B2`,
+		`A1 ＋ "日本語"`,
+		`=SUM((A1,B1))`,
+		`=IF(A1,`,
+	} {
+		p := ExcelParser(Options{Lossless: true})
+		p.Parse(formula)
+		if actual := p.Render(); actual != formula {
+			t.Errorf("expected %q, got %q", formula, actual)
+		}
+	}
+
+	p := ExcelParser(Options{Lossless: true})
+	tokens := p.Parse(`= SUM( A1 ,  "a""b" ) `)
+	tokens[1].TValue = "B$2"
+	tokens[3].TValue = `c"d`
+	if expected, actual := `= SUM( B$2 ,  "c""d" ) `, p.Render(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+	p.Tokens.Items = append(p.Tokens.Items[:2], p.Tokens.Items[4:]...)
+	if expected, actual := `= SUM( B$2    ) `, p.Render(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	p = ExcelParser(Options{Lossless: true})
+	p.Parse(`=A1+ A2`)
+	if _, err := p.ApplySheetEdit("Sheet1", SheetEdit{Type: EditInsertRows, Sheet: "Sheet1", Index: 2, Count: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := `=A1+ A3`, p.Render(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	p = ExcelParser(Options{Lossless: true, Locale: LocaleEuropean})
+	tokens = p.Parse(`=RUNDEN(A1; 1,5)`)
+	tokens[3].TValue = "2.5"
+	if expected, actual := `=RUNDEN(A1; 2,5)`, p.Render(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	p := ExcelParser()
+	p.Parse(`="a""b"&""""`)
+	if expected, actual := `"a""b"&""""`, p.Render(); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
@@ -0,0 +1,175 @@
+package efp
+
+import "strings"
+
+// Precedent types.
+const (
+	PrecedentCell  = "Cell"
+	PrecedentRange = "Range"
+	PrecedentName  = "Name"
+	PrecedentTable = "Table"
+)
+
+// referenceArgument returns whether the argument at the 1-based position of
+// a call of the function with the given number of arguments may be returned
+// as a reference, that is the branches of the functions which return one of
+// their arguments, and the reference and array arguments of the catalog
+// functions which return a reference, such as the first one of OFFSET.
+func referenceArgument(name string, i, n int) bool {
+	switch name = canonicalFunctionName(name); name {
+	case "CHOOSE", "IF":
+		return i >= 2
+	case "IFS":
+		return i%2 == 0
+	case "LET":
+		return i%2 == 0 || i == n
+	case "SWITCH":
+		return i >= 3 && (i%2 == 1 || i == n)
+	case "XLOOKUP":
+		return i == 3 || i == 4
+	}
+	s, ok := functionCatalog[name]
+	if !ok || s.Returns != ArgumentReference || len(s.Arguments) == 0 {
+		return false
+	}
+	kind := s.Arguments[minInt(i, len(s.Arguments))-1]
+	return kind == ArgumentReference || kind == ArgumentArray
+}
+
+// isRangeOperator returns whether the operator token combines references
+// into a reference, that is the range, union and intersection operators.
+func isRangeOperator(t Token) bool {
+	return t.TType == TokenTypeOperatorInfix && (t.TValue == ":" ||
+		t.TSubType == TokenSubTypeUnion || t.TSubType == TokenSubTypeIntersection)
+}
+
+// Precedent directly maps a cell, range, defined name or table read by a
+// formula. Type is one of the precedent types, Workbook is the external
+// workbook name, empty for the current workbook. Sheet is empty for the
+// references to the sheet of the formula. Name is the cell reference without
+// the sheet, such as $A$1:B2, the defined name or the table name, which is
+// empty for the table of the formula. Text is the full text of the
+// reference. Dynamic reports whether each occurrence of the reference is an
+// argument of a function which may return another reference in its place,
+// such as the first argument of OFFSET and INDEX or the branches of IF,
+// which is combined with other references by the range operators only.
+type Precedent struct {
+	Type     string
+	Workbook string
+	Sheet    string
+	Name     string
+	Text     string
+	Dynamic  bool
+}
+
+// newPrecedent returns the precedent for an operand token, false for the
+// operands which are not references.
+func newPrecedent(t Token) (Precedent, bool) {
+	p := Precedent{Text: t.TValue}
+	switch t.TSubType {
+	case TokenSubTypeRange:
+		p.Type = PrecedentRange
+		ref, err := ParseReference(t.TValue)
+		if err != nil {
+			var r Reference
+			if sheet, cells, ok := splitSheet(t.TValue); ok && r.parseSheet(sheet) {
+				p.Workbook, p.Sheet, p.Name = r.Workbook, r.Sheet, cells
+			} else {
+				p.Name = t.TValue
+			}
+			return p, true
+		}
+		if !ref.Range {
+			p.Type = PrecedentCell
+		}
+		p.Workbook, p.Sheet, p.Text = ref.Workbook, ref.Sheet, ref.String()
+		if ref.LastSheet != "" {
+			p.Sheet += ":" + ref.LastSheet
+		}
+		p.Name = p.Text[len(ref.SheetPrefix()):]
+		return p, true
+	case TokenSubTypeName:
+		p.Type, p.Name = PrecedentName, t.TValue
+		if sheet, name, ok := splitSheet(t.TValue); ok {
+			var r Reference
+			r.parseSheet(sheet)
+			p.Workbook, p.Sheet, p.Name = r.Workbook, r.Sheet, name
+		}
+		return p, true
+	case TokenSubTypeStructuredReference:
+		sr, err := ParseStructuredReference(t.TValue)
+		if err != nil {
+			return p, false
+		}
+		p.Type, p.Name, p.Text = PrecedentTable, sr.Table, sr.String()
+		return p, true
+	}
+	return p, false
+}
+
+// precedentArgument directly maps the argument of a function call which
+// contains an operand, call is the index of the function start token, -1
+// for the operands outside of function calls, and position is the 1-based
+// position of the argument.
+type precedentArgument struct {
+	call, position int
+}
+
+// Precedents provides function to get the deduplicated list of the cells,
+// ranges, defined names and tables read by the formula of a token stream, in
+// the order of their first occurrence. The references are matched case
+// insensitively.
+func Precedents(tokens []Token) []Precedent {
+	var (
+		precedents []Precedent
+		arguments  []precedentArgument
+		counts     = map[int]int{}
+		values     = map[precedentArgument]bool{}
+		stack      = []precedentArgument{{call: -1}}
+	)
+	for i, t := range tokens {
+		top := stack[len(stack)-1]
+		switch t.TType {
+		case TokenTypeFunction, TokenTypeSubexpression:
+			if t.TSubType == TokenSubTypeStart {
+				// the subexpressions belong to the argument containing them
+				if t.TType == TokenTypeFunction {
+					top = precedentArgument{call: i, position: 1}
+				}
+				stack = append(stack, top)
+			} else if len(stack) > 1 {
+				if t.TType == TokenTypeFunction {
+					counts[top.call] = top.position
+				}
+				stack = stack[:len(stack)-1]
+			}
+		case TokenTypeArgument:
+			stack[len(stack)-1].position++
+		case TokenTypeOperatorPrefix, TokenTypeOperatorInfix, TokenTypeOperatorPostfix:
+			if !isRangeOperator(t) {
+				values[top] = true
+			}
+		case TokenTypeOperand:
+			if p, ok := newPrecedent(t); ok {
+				precedents, arguments = append(precedents, p), append(arguments, top)
+			}
+		}
+	}
+	var (
+		result []Precedent
+		index  = map[string]int{}
+	)
+	for i, p := range precedents {
+		arg := arguments[i]
+		p.Dynamic = arg.call != -1 && !values[arg] &&
+			referenceArgument(tokens[arg.call].TValue, arg.position, counts[arg.call])
+		key := p.Type + "\x00" + strings.ToUpper(p.Text)
+		if j, ok := index[key]; ok {
+			result[j].Dynamic = result[j].Dynamic && p.Dynamic
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, p)
+	}
+	return result
+}
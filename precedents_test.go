@@ -0,0 +1,53 @@
+package efp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrecedents(t *testing.T) {
+	p := ExcelParser()
+	tokens := p.Parse(`=SUM(A1:B2,a1:b2,Sheet2!C3)+OFFSET(A1,1,1)+INDIRECT("D"&ROW(E5))+'[Book 1.xlsx]Data'!$F$6+TaxRate+[1]!Rate+Table1[[#Headers],[Qty]]+IF(A1,Sheet1:Sheet3!A:A,"A1")`)
+	expected := []Precedent{
+		{Type: PrecedentRange, Name: "A1:B2", Text: "A1:B2"},
+		{Type: PrecedentCell, Sheet: "Sheet2", Name: "C3", Text: "Sheet2!C3"},
+		{Type: PrecedentCell, Name: "A1", Text: "A1"},
+		{Type: PrecedentCell, Name: "E5", Text: "E5"},
+		{Type: PrecedentCell, Workbook: "Book 1.xlsx", Sheet: "Data", Name: "$F$6", Text: "'[Book 1.xlsx]Data'!$F$6"},
+		{Type: PrecedentName, Name: "TaxRate", Text: "TaxRate"},
+		{Type: PrecedentName, Workbook: "1", Name: "Rate", Text: "[1]!Rate"},
+		{Type: PrecedentTable, Name: "Table1", Text: "Table1[[#Headers],[Qty]]"},
+		{Type: PrecedentRange, Sheet: "Sheet1:Sheet3", Name: "A:A", Text: "Sheet1:Sheet3!A:A", Dynamic: true},
+	}
+	actual := Precedents(tokens)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, actual)
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(actual[i], expected[i]) {
+			t.Errorf("expected %+v, got %+v", expected[i], actual[i])
+		}
+	}
+	for formula, expected := range map[string]map[string]bool{
+		`=INDIRECT("D"&ROW(E5))`:                    {"E5": false},
+		`=IF(A1,SUM(B1:B10))`:                       {"A1": false, "B1:B10": false},
+		`=IF(A1,B1:B2 C1,(D1,D2),E1+1)`:             {"A1": false, "B1:B2": true, "C1": true, "D1": true, "D2": true, "E1": false},
+		`=IFS(A1,B1,C1,D1)`:                         {"A1": false, "B1": true, "C1": false, "D1": true},
+		`=SWITCH(A1,B1,C1,D1)`:                      {"A1": false, "B1": false, "C1": true, "D1": true},
+		`=SWITCH(A1,B1,C1,D1,E1)`:                   {"D1": false, "E1": true},
+		`=OFFSET(A1,B1,1)`:                          {"A1": true, "B1": false},
+		`=_xlfn.XLOOKUP(1,B:B,C:C,D1)`:              {"B:B": false, "C:C": true, "D1": true},
+		`=INDEX(A1:A3,2)+RAND()*E1+CHOOSE(2,D1,D2)`: {"A1:A3": true, "E1": false, "D1": true, "D2": true},
+		`=LET(x,A1,x+B1)`:                           {"A1": true, "B1": false},
+	} {
+		p = ExcelParser()
+		for _, precedent := range Precedents(p.Parse(formula)) {
+			if dynamic, ok := expected[precedent.Text]; ok && precedent.Dynamic != dynamic {
+				t.Errorf("%s: %s expected dynamic %t", formula, precedent.Text, dynamic)
+			}
+		}
+	}
+	if actual := Precedents(p.Parse(`=1+"A1"`)); len(actual) != 0 {
+		t.Errorf("expected no precedents, got %+v", actual)
+	}
+}
@@ -0,0 +1,263 @@
+package efp
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// r1c1Part directly maps the row and column part of a reference in the R1C1
+// notation before it is resolved against an anchor cell, such as R[-1]C2.
+type r1c1Part struct {
+	row, col       int
+	rowRel, colRel bool
+	hasRow, hasCol bool
+}
+
+// parseR1C1Number parses the number after the R or C letter at the given
+// position, which is either an absolute number, a relative offset in square
+// brackets, or omitted for a zero relative offset.
+func parseR1C1Number(part string, i int) (n int, relative bool, next int, ok bool) {
+	if i < len(part) && part[i] == BracketOpen {
+		end := strings.IndexByte(part[i:], BracketClose)
+		if end == -1 {
+			return 0, false, i, false
+		}
+		n, err := strconv.Atoi(part[i+1 : i+end])
+		return n, true, i + end + 1, err == nil
+	}
+	start := i
+	for ; i < len(part) && part[i] >= '0' && part[i] <= '9'; i++ {
+	}
+	if i == start {
+		return 0, true, i, true
+	}
+	n, err := strconv.Atoi(part[start:i])
+	return n, false, i, err == nil && n > 0
+}
+
+// parseR1C1Part parses a cell, row or column part of a reference in the R1C1
+// notation, such as R1C1, R[1]C[-1], RC, R2 or C[3].
+func parseR1C1Part(part string) (r1c1Part, bool) {
+	var (
+		p  r1c1Part
+		ok bool
+	)
+	i := 0
+	if i < len(part) && (part[i] == 'R' || part[i] == 'r') {
+		p.hasRow = true
+		if p.row, p.rowRel, i, ok = parseR1C1Number(part, i+1); !ok {
+			return p, false
+		}
+	}
+	if i < len(part) && (part[i] == 'C' || part[i] == 'c') {
+		p.hasCol = true
+		if p.col, p.colRel, i, ok = parseR1C1Number(part, i+1); !ok {
+			return p, false
+		}
+	}
+	return p, i == len(part) && (p.hasRow || p.hasCol)
+}
+
+// isR1C1Reference returns whether the operand value is a syntactically valid
+// reference in the R1C1 notation.
+func isR1C1Reference(ref string) bool {
+	var r Reference
+	sheet, cells, ok := splitSheet(ref)
+	if ok && !r.parseSheet(sheet) {
+		return false
+	}
+	start, end, isRange, ok := splitRange(cells)
+	if !ok {
+		return false
+	}
+	first, ok := parseR1C1Part(start)
+	if !ok {
+		return false
+	}
+	if isRange {
+		last, ok := parseR1C1Part(end)
+		return ok && first.hasRow == last.hasRow && first.hasCol == last.hasCol
+	}
+	return true
+}
+
+// resolveR1C1 returns the row or column number of the R1C1 number for the given
+// anchor number and limit.
+func resolveR1C1(n int, relative bool, anchor, limit int) (int, bool) {
+	if relative {
+		n += anchor
+	}
+	return n, n >= 1 && n <= limit
+}
+
+// cellRef returns the cell part of a reference for the R1C1 part resolved
+// against the anchor cell.
+func (p r1c1Part) cellRef(col, row int) (CellRef, bool) {
+	ref := CellRef{ColAbs: !p.colRel, RowAbs: !p.rowRel}
+	ok := true
+	if p.hasCol {
+		ref.Col, ok = resolveR1C1(p.col, p.colRel, col, MaxColumns)
+	}
+	if p.hasRow && ok {
+		ref.Row, ok = resolveR1C1(p.row, p.rowRel, row, TotalRows)
+	}
+	return ref, ok
+}
+
+// ParseR1C1Reference provides function to parse the value of a range operand
+// in the R1C1 notation, such as Sheet1!R1C1:R[2]C[2], R2 or C[-1], into a
+// structured reference. The relative parts are resolved against the anchor
+// cell at the given column and row numbers.
+func ParseR1C1Reference(ref string, col, row int) (Reference, error) {
+	var r Reference
+	if !isR1C1Reference(ref) {
+		return r, fmt.Errorf("invalid reference %q", ref)
+	}
+	sheet, cells, ok := splitSheet(ref)
+	if ok {
+		r.parseSheet(sheet)
+	}
+	parts := strings.Split(cells, ":")
+	first, _ := parseR1C1Part(parts[0])
+	last := first
+	if r.Range = len(parts) == 2; r.Range {
+		last, _ = parseR1C1Part(parts[1])
+	}
+	var ok1, ok2 bool
+	if r.Start, ok1 = first.cellRef(col, row); ok1 {
+		r.End, ok2 = last.cellRef(col, row)
+	}
+	if !ok1 || !ok2 {
+		return r, fmt.Errorf("reference %q is out of the sheet from R%dC%d", ref, row, col)
+	}
+	// whole row and whole column references are always ranges in the A1
+	// notation, such as R2 for 2:2
+	r.Range = r.Range || !first.hasCol || !first.hasRow
+	if !first.hasCol {
+		r.WholeRow, r.Start.Col, r.End.Col = true, 1, MaxColumns
+		r.Start.ColAbs, r.End.ColAbs = false, false
+	}
+	if !first.hasRow {
+		r.WholeColumn, r.Start.Row, r.End.Row = true, 1, TotalRows
+		r.Start.RowAbs, r.End.RowAbs = false, false
+	}
+	return r, nil
+}
+
+// appendR1C1Number appends the R1C1 text of a row or column number relative
+// to the anchor number.
+func appendR1C1Number(dst []byte, letter byte, n, anchor int, abs bool) []byte {
+	dst = append(dst, letter)
+	if abs {
+		return strconv.AppendInt(dst, int64(n), 10)
+	}
+	if n == anchor {
+		return dst
+	}
+	dst = append(dst, BracketOpen)
+	dst = strconv.AppendInt(dst, int64(n-anchor), 10)
+	return append(dst, BracketClose)
+}
+
+// appendR1C1Part appends the R1C1 text of the cell part of a reference.
+func (r Reference) appendR1C1Part(dst []byte, part CellRef, col, row int) []byte {
+	if !r.WholeColumn {
+		dst = appendR1C1Number(dst, 'R', part.Row, row, part.RowAbs)
+	}
+	if !r.WholeRow {
+		dst = appendR1C1Number(dst, 'C', part.Col, col, part.ColAbs)
+	}
+	return dst
+}
+
+// appendR1C1Cells appends the R1C1 text of the reference without the sheet
+// prefix, relative to the anchor cell at the given column and row numbers.
+func (r Reference) appendR1C1Cells(dst []byte, col, row int) []byte {
+	begin := len(dst)
+	dst = r.appendR1C1Part(dst, r.Start, col, row)
+	if !r.Range {
+		return dst
+	}
+	mid := len(dst)
+	dst = append(dst, ':')
+	dst = r.appendR1C1Part(dst, r.End, col, row)
+	if (r.WholeRow || r.WholeColumn) && bytes.Equal(dst[begin:mid], dst[mid+1:]) {
+		return dst[:mid]
+	}
+	return dst
+}
+
+// R1C1String returns the text of the reference in the R1C1 notation, with the
+// relative parts expressed as offsets from the anchor cell at the given
+// column and row numbers, such as Sheet1!R1C1:R[1]C[1].
+func (r Reference) R1C1String(col, row int) string {
+	return r.SheetPrefix() + string(r.appendR1C1Cells(nil, col, row))
+}
+
+// convertReferences returns a copy of the token stream with the value of
+// each range operand replaced by the given function, which returns whether
+// the operand is converted and whether the converted reference is valid.
+// Operands which aren't converted are kept, invalid references become #REF!
+// errors with the sheet prefix as written, such as Sheet1!#REF!.
+func convertReferences(tokens []Token, convert func(value string) (string, bool, bool)) []Token {
+	result := make([]Token, len(tokens))
+	copy(result, tokens)
+	for i, t := range result {
+		if t.TType != TokenTypeOperand || t.TSubType != TokenSubTypeRange {
+			continue
+		}
+		value, ok, valid := convert(t.TValue)
+		if !ok {
+			continue
+		}
+		if !valid {
+			result[i].TValue, result[i].TSubType = FormulaErrorREF, TokenSubTypeError
+			if sheet, _, ok := splitSheet(t.TValue); ok {
+				result[i].TValue = sheet + "!" + FormulaErrorREF
+			}
+			continue
+		}
+		result[i].TValue = value
+	}
+	return result
+}
+
+// ToR1C1 provides function to convert the range operands of a token stream
+// in the A1 notation to the R1C1 notation, relative to the anchor cell, such
+// as C2.
+func ToR1C1(tokens []Token, cell string) ([]Token, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	return convertReferences(tokens, func(value string) (string, bool, bool) {
+		ref, err := ParseReference(value)
+		if err != nil {
+			return value, false, false
+		}
+		return ref.R1C1String(col, row), true, true
+	}), nil
+}
+
+// ToA1 provides function to convert the range operands of a token stream in
+// the R1C1 notation, such as a stream parsed with the R1C1 option, to the A1
+// notation, relative to the anchor cell, such as C2. References falling out
+// of the sheet become #REF! errors.
+func ToA1(tokens []Token, cell string) ([]Token, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	return convertReferences(tokens, func(value string) (string, bool, bool) {
+		if !isR1C1Reference(value) {
+			return value, false, false
+		}
+		ref, err := ParseR1C1Reference(value, col, row)
+		if err != nil {
+			return value, true, false
+		}
+		return ref.String(), true, true
+	}), nil
+}
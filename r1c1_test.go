@@ -0,0 +1,82 @@
+package efp
+
+import "testing"
+
+func TestParseR1C1Reference(t *testing.T) {
+	for _, c := range []struct {
+		ref, a1, r1c1 string
+	}{
+		{`R1C1`, `$A$1`, `R1C1`},
+		{`rc`, `C5`, `RC`},
+		{`R[-1]C[2]`, `E4`, `R[-1]C[2]`},
+		{`R2C[-1]:R[3]C3`, `B$2:$C8`, `R2C[-1]:R[3]C3`},
+		{`Sheet1!R`, `Sheet1!5:5`, `Sheet1!R`},
+		{`'My Sheet'!R1:R[1]`, `'My Sheet'!$1:6`, `'My Sheet'!R1:R[1]`},
+		{`C[1]:C4`, `D:$D`, `C[1]:C4`},
+	} {
+		r, err := ParseR1C1Reference(c.ref, 3, 5)
+		if err != nil {
+			t.Errorf("%s: %v", c.ref, err)
+			continue
+		}
+		if actual := r.String(); actual != c.a1 {
+			t.Errorf("%s: expected %s, got %s", c.ref, c.a1, actual)
+		}
+		if actual := r.R1C1String(3, 5); actual != c.r1c1 {
+			t.Errorf("%s: expected %s, got %s", c.ref, c.r1c1, actual)
+		}
+	}
+	for _, ref := range []string{``, `A1`, `R0C1`, `R[1`, `R[x]`, `R1C1:C1`, `R1C1:R2C2:R3C3`, `RC[-3]`, `R[-5]`, `Rate`} {
+		if _, err := ParseR1C1Reference(ref, 3, 5); err == nil {
+			t.Errorf("%s: expected error", ref)
+		}
+	}
+}
+
+func TestR1C1Conversion(t *testing.T) {
+	p := ExcelParser()
+	tokens, err := ToR1C1(p.Parse(`=SUM(A1:$B$2,C$3)+Sheet1!D:D+Rate`), "C2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []string
+	for _, token := range tokens {
+		if token.TType == TokenTypeOperand {
+			values = append(values, token.TValue)
+		}
+	}
+	expected := []string{`R[-1]C[-2]:R2C2`, `R3C`, `Sheet1!C[1]`, `Rate`}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], values[i])
+		}
+	}
+
+	p = ExcelParser(Options{R1C1: true})
+	tokens, err = ToA1(p.Parse(`=R[-1]C[-2]+R3C+R[-2]C`), "C2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values = values[:0]
+	for _, token := range tokens {
+		if token.TType == TokenTypeOperand {
+			values = append(values, token.TValue+"|"+token.TSubType)
+		}
+	}
+	expected = []string{`A1|Range`, `C$3|Range`, `#REF!|Error`}
+	for i := range expected {
+		if i >= len(values) || values[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, values)
+			break
+		}
+	}
+	if _, err = ToA1(tokens, "C0"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = ToR1C1(tokens, "1"); err == nil {
+		t.Error("expected error")
+	}
+}
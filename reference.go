@@ -0,0 +1,321 @@
+package efp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CellRef directly maps the cell, column or row part of a reference. Col and
+// Row are 1-based numbers, ColAbs and RowAbs report whether the column and
+// row are absolute ($) or relative.
+type CellRef struct {
+	Col    int
+	Row    int
+	ColAbs bool
+	RowAbs bool
+}
+
+// Reference directly maps a range operand, such as Sheet1!$A$1:$B$2,
+// [data.xls]sheet1!$A$1, Sheet1:Sheet3!A1, A:A or 1:1. Workbook is the name
+// of an external workbook, LastSheet is the last sheet of a 3D reference.
+// Range reports whether the reference has a start and end part, the whole
+// column and whole row references span all rows and columns of the sheet.
+type Reference struct {
+	Workbook    string
+	Sheet       string
+	LastSheet   string
+	Start       CellRef
+	End         CellRef
+	Range       bool
+	WholeColumn bool
+	WholeRow    bool
+}
+
+// isLetter returns whether the byte is an ASCII letter.
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// ColumnNameToNumber provides function to convert the column name, such as
+// AK, to its 1-based number.
+func ColumnNameToNumber(name string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("invalid column name %q", name)
+	}
+	col := 0
+	for i := 0; i < len(name); i++ {
+		if !isLetter(name[i]) {
+			return 0, fmt.Errorf("invalid column name %q", name)
+		}
+		if col = col*26 + int(name[i]|0x20-'a') + 1; col > MaxColumns {
+			return 0, fmt.Errorf("column number exceeds maximum limit %d", MaxColumns)
+		}
+	}
+	return col, nil
+}
+
+// ColumnNumberToName provides function to convert the 1-based column number
+// to its name, such as AK.
+func ColumnNumberToName(num int) (string, error) {
+	if num < 1 || num > MaxColumns {
+		return "", fmt.Errorf("column number %d out of range", num)
+	}
+	var name []byte
+	for ; num > 0; num = (num - 1) / 26 {
+		name = append([]byte{byte('A' + (num-1)%26)}, name...)
+	}
+	return string(name), nil
+}
+
+// CellNameToCoordinates provides function to convert the cell name, such as
+// $A$1 or B2, to its column and row numbers.
+func CellNameToCoordinates(cell string) (int, int, error) {
+	part, ok := parseCellRef(cell)
+	if !ok || part.Col == 0 || part.Row == 0 {
+		return 0, 0, fmt.Errorf("invalid cell name %q", cell)
+	}
+	return part.Col, part.Row, nil
+}
+
+// CoordinatesToCellName provides function to convert the column and row
+// numbers to the cell name, the optional abs argument returns an absolute
+// cell name, such as $A$1.
+func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
+	if row < 1 || row > TotalRows {
+		return "", fmt.Errorf("row number %d out of range", row)
+	}
+	name, err := ColumnNumberToName(col)
+	if err != nil {
+		return "", err
+	}
+	if len(abs) > 0 && abs[0] {
+		return "$" + name + "$" + strconv.Itoa(row), nil
+	}
+	return name + strconv.Itoa(row), nil
+}
+
+// parseCellRef parses a cell, column or row part of a reference, such as
+// $A$1, A or 1, the missing column or row number is zero.
+func parseCellRef(part string) (CellRef, bool) {
+	var ref CellRef
+	i := 0
+	if i < len(part) && part[i] == '$' {
+		ref.ColAbs = true
+		i++
+	}
+	for ; i < len(part) && isLetter(part[i]); i++ {
+		if ref.Col = ref.Col*26 + int(part[i]|0x20-'a') + 1; ref.Col > MaxColumns {
+			return ref, false
+		}
+	}
+	if ref.Col == 0 && ref.ColAbs {
+		ref.ColAbs, ref.RowAbs = false, true
+	}
+	if i < len(part) && part[i] == '$' && ref.Col > 0 {
+		ref.RowAbs = true
+		i++
+	}
+	digits := i
+	for ; i < len(part) && part[i] >= '0' && part[i] <= '9'; i++ {
+		if ref.Row = ref.Row*10 + int(part[i]-'0'); ref.Row > TotalRows {
+			return ref, false
+		}
+	}
+	if i != len(part) || (i > digits && ref.Row == 0) || (ref.Row == 0 && ref.RowAbs) {
+		return ref, false
+	}
+	return ref, ref.Col > 0 || ref.Row > 0
+}
+
+// splitSheet splits a reference into the sheet part and the cell part at the
+// last exclamation mark outside of single quotes.
+func splitSheet(ref string) (string, string, bool) {
+	quoted := false
+	for i := len(ref) - 1; i >= 0; i-- {
+		switch ref[i] {
+		case QuoteSingle:
+			quoted = !quoted
+		case '!':
+			if !quoted {
+				return ref[:i], ref[i+1:], true
+			}
+		}
+	}
+	return "", ref, false
+}
+
+// parseSheet parses the sheet part of a reference into the workbook, the
+// sheet and the last sheet of a 3D reference.
+func (r *Reference) parseSheet(sheet string) bool {
+	if len(sheet) > 1 && sheet[0] == QuoteSingle && sheet[len(sheet)-1] == QuoteSingle {
+		sheet = strings.Replace(sheet[1:len(sheet)-1], "''", "'", -1)
+	}
+	if open := strings.LastIndexByte(sheet, BracketOpen); open != -1 {
+		end := strings.IndexByte(sheet[open:], BracketClose)
+		if end == -1 {
+			return false
+		}
+		r.Workbook = sheet[:open] + sheet[open+1:open+end]
+		sheet = sheet[open+end+1:]
+	}
+	if i := strings.IndexByte(sheet, ':'); i != -1 {
+		sheet, r.LastSheet = sheet[:i], sheet[i+1:]
+		if r.LastSheet == "" {
+			return false
+		}
+	}
+	r.Sheet = sheet
+	return sheet != ""
+}
+
+// ParseReference provides function to parse the value of a range operand,
+// such as Sheet1!$A$1:$B$2, 'My Sheet'!A1, [data.xls]sheet1!$A$1, A:A or
+// 1:1, into a structured reference. Defined names, structured references and
+// other texts which are not cell references return an error.
+func ParseReference(ref string) (Reference, error) {
+	r, ok := parseReference(ref)
+	if !ok {
+		return r, fmt.Errorf("invalid reference %q", ref)
+	}
+	return r, nil
+}
+
+// splitRange splits the cell part of a reference at the colon into the start
+// and end parts, the end part is empty for a single cell, column or row. It
+// returns false if there is more than one colon.
+func splitRange(cells string) (string, string, bool, bool) {
+	i := strings.IndexByte(cells, ':')
+	if i == -1 {
+		return cells, "", false, true
+	}
+	return cells[:i], cells[i+1:], true, strings.IndexByte(cells[i+1:], ':') == -1
+}
+
+// parseReference parses the value of a range operand as ParseReference, and
+// returns false instead of an error for the invalid references.
+func parseReference(ref string) (Reference, bool) {
+	var r Reference
+	sheet, cells, ok := splitSheet(ref)
+	if ok && !r.parseSheet(sheet) {
+		return r, false
+	}
+	first, last, isRange, ok := splitRange(cells)
+	if !ok {
+		return r, false
+	}
+	start, ok1 := parseCellRef(first)
+	end, ok2 := start, ok1
+	if isRange {
+		end, ok2 = parseCellRef(last)
+	}
+	if !ok1 || !ok2 || (start.Col == 0) != (end.Col == 0) || (start.Row == 0) != (end.Row == 0) ||
+		(!isRange && (start.Col == 0 || start.Row == 0)) {
+		return r, false
+	}
+	r.Start, r.End, r.Range = start, end, isRange
+	if start.Row == 0 {
+		r.WholeColumn, r.Start.Row, r.End.Row = true, 1, TotalRows
+	}
+	if start.Col == 0 {
+		r.WholeRow, r.Start.Col, r.End.Col = true, 1, MaxColumns
+	}
+	return r, true
+}
+
+// Bounds returns the top left and bottom right column and row numbers of the
+// cells covered by the reference.
+func (r Reference) Bounds() (col1, row1, col2, row2 int) {
+	return minInt(r.Start.Col, r.End.Col), minInt(r.Start.Row, r.End.Row),
+		maxInt(r.Start.Col, r.End.Col), maxInt(r.Start.Row, r.End.Row)
+}
+
+// formatCellRef returns the text of the cell part of a reference, the column
+// or the row is omitted for whole row or whole column references.
+func formatCellRef(part CellRef, wholeColumn, wholeRow bool) string {
+	var text strings.Builder
+	if !wholeRow {
+		if part.ColAbs {
+			text.WriteByte('$')
+		}
+		name, _ := ColumnNumberToName(part.Col)
+		text.WriteString(name)
+	}
+	if !wholeColumn {
+		if part.RowAbs {
+			text.WriteByte('$')
+		}
+		text.WriteString(strconv.Itoa(part.Row))
+	}
+	return text.String()
+}
+
+// SheetPrefix returns the workbook and sheet part of the reference with the
+// trailing exclamation mark, quoted when required, or an empty string for a
+// reference without sheet.
+func (r Reference) SheetPrefix() string {
+	if r.Sheet == "" && r.Workbook == "" {
+		return ""
+	}
+	sheet := r.Sheet
+	if r.LastSheet != "" {
+		sheet += ":" + r.LastSheet
+	}
+	quote := needsQuote(r.Sheet) || (r.LastSheet != "" && needsQuote(r.LastSheet))
+	if r.Workbook != "" {
+		dir, name := "", r.Workbook
+		if i := strings.LastIndexAny(name, `\/`); i != -1 {
+			dir, name = name[:i+1], name[i+1:]
+		}
+		sheet = dir + string(BracketOpen) + name + string(BracketClose) + sheet
+		quote = quote || dir != "" || needsQuote(name)
+	}
+	if quote {
+		return string(QuoteSingle) + strings.Replace(sheet, "'", "''", -1) + string(QuoteSingle) + "!"
+	}
+	return sheet + "!"
+}
+
+// String returns the text of the reference, such as 'My Sheet'!$A$1:$B$2.
+func (r Reference) String() string {
+	text := r.SheetPrefix() + formatCellRef(r.Start, r.WholeColumn, r.WholeRow)
+	if r.Range {
+		text += ":" + formatCellRef(r.End, r.WholeColumn, r.WholeRow)
+	}
+	return text
+}
+
+// needsQuote returns whether the sheet name must be enclosed in single quotes
+// in a reference.
+func needsQuote(sheet string) bool {
+	if sheet == "" {
+		return false
+	}
+	for i, r := range sheet {
+		if !(unicode.IsLetter(r) || r == '_' || r == '.' || (i > 0 && unicode.IsDigit(r))) {
+			return true
+		}
+	}
+	if _, ok := parseCellRef(sheet); ok {
+		return true
+	}
+	upper := strings.ToUpper(sheet)
+	return upper == "TRUE" || upper == "FALSE" || isR1C1Like(upper)
+}
+
+// isR1C1Like returns whether the upper case text looks like a reference in the
+// R1C1 notation, such as R, C, R1 or R1C1, which can't be sheet names without
+// quotes.
+func isR1C1Like(text string) bool {
+	i := 0
+	if i < len(text) && text[i] == 'R' {
+		for i++; i < len(text) && text[i] >= '0' && text[i] <= '9'; i++ {
+		}
+	}
+	if i < len(text) && text[i] == 'C' {
+		for i++; i < len(text) && text[i] >= '0' && text[i] <= '9'; i++ {
+		}
+	}
+	return i > 0 && i == len(text)
+}
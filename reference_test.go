@@ -0,0 +1,77 @@
+package efp
+
+import "testing"
+
+func TestParseReference(t *testing.T) {
+	for _, c := range []struct {
+		ref, formatted string
+		expected       Reference
+	}{
+		{`A1`, `A1`, Reference{Start: CellRef{Col: 1, Row: 1}, End: CellRef{Col: 1, Row: 1}}},
+		{`sheet1!$A$1:$B$2`, `sheet1!$A$1:$B$2`, Reference{Sheet: "sheet1",
+			Start: CellRef{Col: 1, Row: 1, ColAbs: true, RowAbs: true},
+			End:   CellRef{Col: 2, Row: 2, ColAbs: true, RowAbs: true}, Range: true}},
+		{`[data.xls]sheet1!$A1`, `[data.xls]sheet1!$A1`, Reference{Workbook: "data.xls", Sheet: "sheet1",
+			Start: CellRef{Col: 1, Row: 1, ColAbs: true}, End: CellRef{Col: 1, Row: 1, ColAbs: true}}},
+		{`'C:\My Files\[Book 1.xlsx]Sheet 1'!A$1`, `'C:\My Files\[Book 1.xlsx]Sheet 1'!A$1`,
+			Reference{Workbook: `C:\My Files\Book 1.xlsx`, Sheet: "Sheet 1",
+				Start: CellRef{Col: 1, Row: 1, RowAbs: true}, End: CellRef{Col: 1, Row: 1, RowAbs: true}}},
+		{`Sheet1:Sheet3!XFD1048576`, `Sheet1:Sheet3!XFD1048576`, Reference{Sheet: "Sheet1", LastSheet: "Sheet3",
+			Start: CellRef{Col: MaxColumns, Row: TotalRows}, End: CellRef{Col: MaxColumns, Row: TotalRows}}},
+		{`'It''s'!B2`, `'It''s'!B2`, Reference{Sheet: "It's",
+			Start: CellRef{Col: 2, Row: 2}, End: CellRef{Col: 2, Row: 2}}},
+		{`My Sheet!B2`, `'My Sheet'!B2`, Reference{Sheet: "My Sheet",
+			Start: CellRef{Col: 2, Row: 2}, End: CellRef{Col: 2, Row: 2}}},
+		{`A:$C`, `A:$C`, Reference{Start: CellRef{Col: 1, Row: 1}, End: CellRef{Col: 3, Row: TotalRows, ColAbs: true},
+			Range: true, WholeColumn: true}},
+		{`$1:2`, `$1:2`, Reference{Start: CellRef{Col: 1, Row: 1, RowAbs: true}, End: CellRef{Col: MaxColumns, Row: 2},
+			Range: true, WholeRow: true}},
+	} {
+		r, err := ParseReference(c.ref)
+		if err != nil {
+			t.Errorf("%s: %v", c.ref, err)
+			continue
+		}
+		if r != c.expected {
+			t.Errorf("%s: expected %+v, got %+v", c.ref, c.expected, r)
+		}
+		if actual := r.String(); actual != c.formatted {
+			t.Errorf("%s: expected %s, got %s", c.ref, c.formatted, actual)
+		}
+	}
+	for _, ref := range []string{``, `AName`, `A0`, `XFE1`, `A1048577`, `A1:B`, `A:1`, `A`, `1`, `A1:B2:C3`,
+		`!A1`, `Sheet1:!A1`, `[1]!Name`, `A$`, `$$A1`, `Table1[Sales]`, `R1C1`} {
+		if _, err := ParseReference(ref); err == nil {
+			t.Errorf("%s: expected error", ref)
+		}
+	}
+}
+
+func TestCellNameConversion(t *testing.T) {
+	for name, num := range map[string]int{"A": 1, "Z": 26, "AA": 27, "AK": 37, "XFD": MaxColumns} {
+		if n, err := ColumnNameToNumber(name); err != nil || n != num {
+			t.Errorf("%s: expected %d, got %d, %v", name, num, n, err)
+		}
+		if s, err := ColumnNumberToName(num); err != nil || s != name {
+			t.Errorf("%d: expected %s, got %s, %v", num, name, s, err)
+		}
+	}
+	if _, err := ColumnNameToNumber("XFE"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := ColumnNumberToName(0); err == nil {
+		t.Error("expected error")
+	}
+	if col, row, err := CellNameToCoordinates("$C$5"); err != nil || col != 3 || row != 5 {
+		t.Errorf("expected 3, 5, got %d, %d, %v", col, row, err)
+	}
+	if _, _, err := CellNameToCoordinates("C"); err == nil {
+		t.Error("expected error")
+	}
+	if cell, err := CoordinatesToCellName(3, 5, true); err != nil || cell != "$C$5" {
+		t.Errorf("expected $C$5, got %s, %v", cell, err)
+	}
+	if _, err := CoordinatesToCellName(1, 0); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,120 @@
+package efp
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSheetNameLength is the maximum number of characters of a sheet name.
+const maxSheetNameLength = 31
+
+// convertSheets returns a copy of the token stream with the sheet part of each
+// sheet qualified range or defined name operand updated by the given
+// function, which returns whether the sheet part is changed and whether the
+// updated reference is valid. The cell or name part is kept as written, the
+// invalid references become #REF! errors.
+func convertSheets(tokens []Token, convert func(r *Reference) (bool, bool)) []Token {
+	result := make([]Token, len(tokens))
+	copy(result, tokens)
+	for i, t := range result {
+		if t.TType != TokenTypeOperand || (t.TSubType != TokenSubTypeRange && t.TSubType != TokenSubTypeName) {
+			continue
+		}
+		var r Reference
+		sheet, cells, ok := splitSheet(t.TValue)
+		if !ok || !r.parseSheet(sheet) || r.Workbook != "" {
+			continue
+		}
+		changed, valid := convert(&r)
+		if !changed {
+			continue
+		}
+		if !valid {
+			result[i].TValue, result[i].TSubType = FormulaErrorREF, TokenSubTypeError
+			continue
+		}
+		result[i].TValue = r.SheetPrefix() + cells
+	}
+	return result
+}
+
+// checkSheetName provides function to check whether the text is a valid
+// sheet name.
+func checkSheetName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxSheetNameLength {
+		return fmt.Errorf("the sheet name length must be from 1 to %d characters", maxSheetNameLength)
+	}
+	if strings.ContainsAny(name, `:\/?*[]`) || name[0] == QuoteSingle || name[len(name)-1] == QuoteSingle {
+		return fmt.Errorf("invalid sheet name %q", name)
+	}
+	return nil
+}
+
+// RenameSheet provides function to update the range and defined name
+// operands of a token stream for renaming the sheet, including the first and
+// last sheets of 3D references, such as Sheet1:Sheet3!A1. The sheet names are
+// matched case insensitively, and quoted or unquoted as required by the new
+// name. The references to external workbooks are kept.
+func RenameSheet(tokens []Token, oldName, newName string) ([]Token, error) {
+	if err := checkSheetName(newName); err != nil {
+		return nil, err
+	}
+	return convertSheets(tokens, func(r *Reference) (bool, bool) {
+		changed := false
+		if strings.EqualFold(r.Sheet, oldName) {
+			r.Sheet, changed = newName, true
+		}
+		if strings.EqualFold(r.LastSheet, oldName) {
+			r.LastSheet, changed = newName, true
+		}
+		return changed, true
+	}), nil
+}
+
+// sheetIndex returns the index of the sheet in the ordered list of sheet
+// names, or -1 if it's not found.
+func sheetIndex(sheets []string, name string) int {
+	for i, sheet := range sheets {
+		if strings.EqualFold(sheet, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DeleteSheet provides function to update the range and defined name
+// operands of a token stream for deleting the sheet, the references to the
+// deleted sheet become #REF! errors. The optional sheets argument is the
+// order of the sheets in the workbook before deleting, when it's given the
+// 3D references starting or ending at the deleted sheet are narrowed to the
+// adjacent sheet, otherwise they also become #REF! errors.
+func DeleteSheet(tokens []Token, name string, sheets ...string) []Token {
+	return convertSheets(tokens, func(r *Reference) (bool, bool) {
+		first, last := strings.EqualFold(r.Sheet, name), strings.EqualFold(r.LastSheet, name)
+		if !first && !last {
+			return false, true
+		}
+		if r.LastSheet == "" {
+			return true, false
+		}
+		i, j := sheetIndex(sheets, r.Sheet), sheetIndex(sheets, r.LastSheet)
+		if i == -1 || j == -1 || i == j {
+			return true, false
+		}
+		step := 1
+		if i > j {
+			step = -1
+		}
+		if first {
+			r.Sheet = sheets[i+step]
+		}
+		if last {
+			r.LastSheet = sheets[j-step]
+		}
+		if strings.EqualFold(r.Sheet, r.LastSheet) {
+			r.LastSheet = ""
+		}
+		return true, true
+	})
+}
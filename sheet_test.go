@@ -0,0 +1,62 @@
+package efp
+
+import "testing"
+
+func TestRenameSheet(t *testing.T) {
+	for _, c := range []struct {
+		formula, oldName, newName, expected string
+	}{
+		{`='My Sheet'!A1+Sheet2!B2`, "My Sheet", "Data", `Data!A1+Sheet2!B2`},
+		{`=SUM(Sheet1!A1:B2)+sheet1!Total+A1`, "Sheet1", "Q1 Sales", `SUM('Q1 Sales'!A1:B2)+'Q1 Sales'!Total+A1`},
+		{`=SUM(Sheet1:Sheet3!A1)`, "Sheet3", "It's", `SUM('Sheet1:It''s'!A1)`},
+		{`='It''s'!A1&"Sheet1!A1"`, "It's", "Sheet1", `Sheet1!A1&"Sheet1!A1"`},
+		{`=[Book1.xlsx]Sheet1!A1+'C:\[Book 2.xlsx]Sheet1'!A1`, "Sheet1", "Data", `[Book1.xlsx]Sheet1!A1+'C:\[Book 2.xlsx]Sheet1'!A1`},
+		{`=Sheet1!R1C1`, "Sheet1", "R1C1", `'R1C1'!R1C1`},
+	} {
+		p := ExcelParser()
+		tokens, err := RenameSheet(p.Parse(c.formula), c.oldName, c.newName)
+		if err != nil {
+			t.Errorf("%s: %v", c.formula, err)
+			continue
+		}
+		if actual := renderTokens(tokens, LocaleEnglish); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+	for _, name := range []string{"", "a:b", "[x]", "'x", "abcdefghijklmnopqrstuvwxyz123456"} {
+		if _, err := RenameSheet(nil, "Sheet1", name); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDeleteSheet(t *testing.T) {
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4"}
+	for _, c := range []struct {
+		formula, name, expected string
+		sheets                  []string
+	}{
+		{`=Sheet2!A1+SUM(sheet2!Total,Sheet1!A1)`, "Sheet2", `#REF!+SUM(#REF!,Sheet1!A1)`, nil},
+		{`=SUM(Sheet1:Sheet3!A1)+SUM(Sheet3:Sheet1!A1)`, "Sheet1", `SUM(Sheet2:Sheet3!A1)+SUM(Sheet3:Sheet2!A1)`, sheets},
+		{`=SUM(Sheet1:Sheet3!A1)+SUM(Sheet2:Sheet3!A1)`, "Sheet3", `SUM(Sheet1:Sheet2!A1)+SUM(Sheet2!A1)`, sheets},
+		{`=SUM(Sheet1:Sheet4!A1)`, "Sheet2", `SUM(Sheet1:Sheet4!A1)`, sheets},
+		{`=SUM(Sheet1:Sheet3!A1)`, "Sheet3", `SUM(#REF!)`, nil},
+		{`='My Sheet'!A1+A1`, "my sheet", `#REF!+A1`, nil},
+	} {
+		p := ExcelParser()
+		tokens := DeleteSheet(p.Parse(c.formula), c.name, c.sheets...)
+		if actual := renderTokens(tokens, LocaleEnglish); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+}
+
+func TestQuotedSheetRoundTrip(t *testing.T) {
+	for _, formula := range []string{`'My Sheet'!A1`, `SUM('It''s'!A1:B2)`, `'[Book 1.xlsx]Sheet1'!Name`} {
+		p := ExcelParser()
+		p.Parse(formula)
+		if actual := p.Render(); actual != formula {
+			t.Errorf("expected %s, got %s", formula, actual)
+		}
+	}
+}
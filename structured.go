@@ -0,0 +1,232 @@
+package efp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Structured reference item specifiers.
+const (
+	StructuredItemAll     = "#All"
+	StructuredItemData    = "#Data"
+	StructuredItemHeaders = "#Headers"
+	StructuredItemTotals  = "#Totals"
+	StructuredItemThisRow = "#This Row"
+)
+
+// structuredItems maps the lower case item specifiers to their canonical
+// form.
+var structuredItems = map[string]string{
+	"#all":      StructuredItemAll,
+	"#data":     StructuredItemData,
+	"#headers":  StructuredItemHeaders,
+	"#totals":   StructuredItemTotals,
+	"#this row": StructuredItemThisRow,
+}
+
+// StructuredReference directly maps a table structured reference operand,
+// such as Table1[[#Headers],[Region]:[Qty]] or [@Price]. Table is empty for
+// references inside the table, Items are the canonical item specifiers, the
+// "@" shorthand is reported as #This Row. ColumnStart and ColumnEnd are the
+// unescaped column names, both are empty for references without column, and
+// ColumnEnd is empty for references to a single column.
+type StructuredReference struct {
+	Table       string
+	Items       []string
+	ColumnStart string
+	ColumnEnd   string
+}
+
+// bracketContent returns the content between the square bracket at the given
+// offset and its matching close bracket, and the offset after the close
+// bracket. Characters escaped by single quotes are skipped.
+func bracketContent(text string, i int) (string, int, bool) {
+	depth := 0
+	for j := i; j < len(text); j++ {
+		switch text[j] {
+		case QuoteSingle:
+			j++
+		case BracketOpen:
+			depth++
+		case BracketClose:
+			if depth--; depth == 0 {
+				return text[i+1 : j], j + 1, true
+			}
+		}
+	}
+	return "", i, false
+}
+
+// isTableName returns whether the text is a valid table name, such as
+// Table1 or Sales_2024.
+func isTableName(name string) bool {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '\\' || (i > 0 && (unicode.IsDigit(r) || r == '.'))) {
+			return false
+		}
+	}
+	return true
+}
+
+// unescapeColumn returns the column name with the single quote escapes
+// removed.
+func unescapeColumn(name string) string {
+	if strings.IndexByte(name, QuoteSingle) == -1 {
+		return name
+	}
+	var text strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == QuoteSingle && i+1 < len(name) {
+			i++
+		}
+		text.WriteByte(name[i])
+	}
+	return text.String()
+}
+
+// escapeColumn returns the column name with the special characters escaped by
+// single quotes.
+func escapeColumn(name string) string {
+	var text strings.Builder
+	for _, r := range name {
+		if r == BracketOpen || r == BracketClose || r == '#' || r == QuoteSingle {
+			text.WriteByte(QuoteSingle)
+		}
+		text.WriteRune(r)
+	}
+	return text.String()
+}
+
+// addSpecifier provides function to add an item or column specifier to the
+// structured reference.
+func (sr *StructuredReference) addSpecifier(spec string, columnEnd bool) bool {
+	if columnEnd {
+		if sr.ColumnStart == "" || sr.ColumnEnd != "" || strings.HasPrefix(spec, "#") {
+			return false
+		}
+		sr.ColumnEnd = unescapeColumn(spec)
+		return true
+	}
+	if strings.HasPrefix(spec, "#") {
+		item, ok := structuredItems[strings.ToLower(spec)]
+		if ok {
+			sr.Items = append(sr.Items, item)
+		}
+		return ok
+	}
+	if sr.ColumnStart != "" || spec == "" {
+		return false
+	}
+	sr.ColumnStart = unescapeColumn(spec)
+	return true
+}
+
+// parseSpecifiers provides function to parse the content between the outer
+// brackets of a structured reference, which is empty, a single specifier,
+// "@" followed by columns, or a comma separated list of bracketed
+// specifiers.
+func (sr *StructuredReference) parseSpecifiers(text string) bool {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "@") {
+		sr.Items = append(sr.Items, StructuredItemThisRow)
+		if text = strings.TrimSpace(text[1:]); text == "" {
+			return true
+		}
+	}
+	if text == "" {
+		return len(sr.Items) == 0
+	}
+	if text[0] != BracketOpen {
+		for i := 0; i < len(text); i++ {
+			if text[i] == QuoteSingle {
+				i++
+			} else if text[i] == BracketOpen || text[i] == BracketClose {
+				return false
+			}
+		}
+		return sr.addSpecifier(text, false)
+	}
+	columnEnd := false
+	for i := 0; i < len(text); {
+		if text[i] != BracketOpen {
+			return false
+		}
+		spec, next, ok := bracketContent(text, i)
+		if !ok || !sr.addSpecifier(strings.TrimSpace(spec), columnEnd) {
+			return false
+		}
+		i, columnEnd = next, false
+		for i < len(text) && text[i] == ' ' {
+			i++
+		}
+		if i < len(text) {
+			switch text[i] {
+			case ':':
+				columnEnd = true
+			case ',':
+			default:
+				return false
+			}
+			for i++; i < len(text) && text[i] == ' '; i++ {
+			}
+			if i == len(text) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ParseStructuredReference provides function to parse the value of a table
+// structured reference operand, such as Table1[Sales], Table1[[#Headers],
+// [Region]:[Qty]] or [@Price], into its table name, item specifiers and
+// column range.
+func ParseStructuredReference(ref string) (StructuredReference, error) {
+	var sr StructuredReference
+	open := strings.IndexByte(ref, BracketOpen)
+	if open == -1 || !isTableName(ref[:open]) {
+		return sr, fmt.Errorf("invalid structured reference %q", ref)
+	}
+	text, end, ok := bracketContent(ref, open)
+	if !ok || end != len(ref) || !sr.parseSpecifiers(text) {
+		return sr, fmt.Errorf("invalid structured reference %q", ref)
+	}
+	sr.Table = ref[:open]
+	return sr, nil
+}
+
+// String returns the text of the structured reference, such as
+// Table1[[#Headers],[Region]:[Qty]].
+func (sr StructuredReference) String() string {
+	var columns string
+	if sr.ColumnStart != "" {
+		columns = "[" + escapeColumn(sr.ColumnStart) + "]"
+		if sr.ColumnEnd != "" {
+			columns += ":[" + escapeColumn(sr.ColumnEnd) + "]"
+		}
+	}
+	if len(sr.Items) == 1 && sr.Items[0] == StructuredItemThisRow {
+		return sr.Table + "[@" + columns + "]"
+	}
+	if len(sr.Items) == 0 {
+		if columns == "" {
+			return sr.Table + "[]"
+		}
+		if sr.ColumnEnd == "" {
+			return sr.Table + columns
+		}
+		return sr.Table + "[" + columns + "]"
+	}
+	specifiers := make([]string, 0, len(sr.Items)+1)
+	for _, item := range sr.Items {
+		specifiers = append(specifiers, "["+item+"]")
+	}
+	if columns != "" {
+		specifiers = append(specifiers, columns)
+	}
+	if len(specifiers) == 1 {
+		return sr.Table + specifiers[0]
+	}
+	return sr.Table + "[" + strings.Join(specifiers, ",") + "]"
+}
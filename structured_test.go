@@ -0,0 +1,76 @@
+package efp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStructuredReference(t *testing.T) {
+	for _, c := range []struct {
+		ref, formatted string
+		expected       StructuredReference
+	}{
+		{`Table1[Sales]`, `Table1[Sales]`, StructuredReference{Table: "Table1", ColumnStart: "Sales"}},
+		{`Table1[]`, `Table1[]`, StructuredReference{Table: "Table1"}},
+		{`Table1[#ALL]`, `Table1[#All]`, StructuredReference{Table: "Table1", Items: []string{StructuredItemAll}}},
+		{`Table1[[#Headers],[Region]:[Qty]]`, `Table1[[#Headers],[Region]:[Qty]]`, StructuredReference{
+			Table: "Table1", Items: []string{StructuredItemHeaders}, ColumnStart: "Region", ColumnEnd: "Qty"}},
+		{`Table1[[#Data], [#Totals], [Qty]]`, `Table1[[#Data],[#Totals],[Qty]]`, StructuredReference{
+			Table: "Table1", Items: []string{StructuredItemData, StructuredItemTotals}, ColumnStart: "Qty"}},
+		{`[@Price]`, `[@[Price]]`, StructuredReference{Items: []string{StructuredItemThisRow}, ColumnStart: "Price"}},
+		{`Sales[@[Unit Price]:[Qty]]`, `Sales[@[Unit Price]:[Qty]]`, StructuredReference{Table: "Sales",
+			Items: []string{StructuredItemThisRow}, ColumnStart: "Unit Price", ColumnEnd: "Qty"}},
+		{`[[#This Row],[Total '# Sold]]`, `[@[Total '# Sold]]`, StructuredReference{
+			Items: []string{StructuredItemThisRow}, ColumnStart: "Total # Sold"}},
+		{`[@]`, `[@]`, StructuredReference{Items: []string{StructuredItemThisRow}}},
+		{`T[[A]:[B]]`, `T[[A]:[B]]`, StructuredReference{Table: "T", ColumnStart: "A", ColumnEnd: "B"}},
+	} {
+		sr, err := ParseStructuredReference(c.ref)
+		if err != nil {
+			t.Errorf("%s: %v", c.ref, err)
+			continue
+		}
+		if !reflect.DeepEqual(sr, c.expected) {
+			t.Errorf("%s: expected %+v, got %+v", c.ref, c.expected, sr)
+		}
+		if actual := sr.String(); actual != c.formatted {
+			t.Errorf("%s: expected %s, got %s", c.ref, c.formatted, actual)
+		}
+	}
+	for _, ref := range []string{``, `Table1`, `Table1[Sales`, `Table1[Sales]x`, `[1]!Name`, `Sheet1!T[A]`,
+		`T[#Foo]`, `T[[A],[B]]`, `T[[A]:[#All]]`, `T[[A],]`, `T[[A] [B]]`, `R[1]C[2]`} {
+		if _, err := ParseStructuredReference(ref); err == nil {
+			t.Errorf("%s: expected error", ref)
+		}
+	}
+}
+
+func TestStructuredReferenceTokens(t *testing.T) {
+	for _, c := range []struct {
+		formula  string
+		expected []string
+	}{
+		{`=SUM(Table1[Sales],1)`, []string{`Table1[Sales]`, `1`}},
+		{`=COUNTA(Table1[[#Headers],[Region]:[Qty]])`, []string{`Table1[[#Headers],[Region]:[Qty]]`}},
+		{`=[@Price]*[@Qty]`, []string{`[@Price]`, `[@Qty]`}},
+		{`=T['[x']]&"a"`, []string{`T['[x']]`, `a`}},
+	} {
+		p := ExcelParser()
+		var values []string
+		for _, token := range p.Parse(c.formula) {
+			if token.TType != TokenTypeOperand {
+				continue
+			}
+			values = append(values, token.TValue)
+			if token.TValue[0] != 'a' && token.TValue != "1" && token.TSubType != TokenSubTypeStructuredReference {
+				t.Errorf("%s: unexpected subtype %s of %s", c.formula, token.TSubType, token.TValue)
+			}
+		}
+		if !reflect.DeepEqual(values, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.formula, c.expected, values)
+		}
+		if actual := p.Render(); actual != c.formula[1:] {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.formula[1:], actual)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package efp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TokenType is the integer form of the token type of the TType field, which
+// can be switched over exhaustively.
+type TokenType uint8
+
+// Typed token types, the zero value is TypeNoop.
+const (
+	TypeNoop TokenType = iota
+	TypeOperand
+	TypeFunction
+	TypeSubexpression
+	TypeArgument
+	TypeOperatorPrefix
+	TypeOperatorInfix
+	TypeOperatorPostfix
+	TypeWhitespace
+	TypeUnknown
+)
+
+// tokenTypeNames directly maps the typed token types to the token type
+// strings.
+var tokenTypeNames = [...]string{
+	TypeNoop:            TokenTypeNoop,
+	TypeOperand:         TokenTypeOperand,
+	TypeFunction:        TokenTypeFunction,
+	TypeSubexpression:   TokenTypeSubexpression,
+	TypeArgument:        TokenTypeArgument,
+	TypeOperatorPrefix:  TokenTypeOperatorPrefix,
+	TypeOperatorInfix:   TokenTypeOperatorInfix,
+	TypeOperatorPostfix: TokenTypeOperatorPostfix,
+	TypeWhitespace:      TokenTypeWhitespace,
+	TypeUnknown:         TokenTypeUnknown,
+}
+
+// TokenSubType is the integer form of the token subtype of the TSubType
+// field, which can be switched over exhaustively.
+type TokenSubType uint8
+
+// Typed token subtypes, the zero value SubTypeNone is the empty subtype.
+const (
+	SubTypeNone TokenSubType = iota
+	SubTypeStart
+	SubTypeStop
+	SubTypeText
+	SubTypeNumber
+	SubTypeLogical
+	SubTypeError
+	SubTypeRange
+	SubTypeName
+	SubTypeStructuredReference
+	SubTypeMath
+	SubTypeConcatenation
+	SubTypeIntersection
+	SubTypeUnion
+)
+
+// tokenSubTypeNames directly maps the typed token subtypes to the token
+// subtype strings.
+var tokenSubTypeNames = [...]string{
+	SubTypeNone:                "",
+	SubTypeStart:               TokenSubTypeStart,
+	SubTypeStop:                TokenSubTypeStop,
+	SubTypeText:                TokenSubTypeText,
+	SubTypeNumber:              TokenSubTypeNumber,
+	SubTypeLogical:             TokenSubTypeLogical,
+	SubTypeError:               TokenSubTypeError,
+	SubTypeRange:               TokenSubTypeRange,
+	SubTypeName:                TokenSubTypeName,
+	SubTypeStructuredReference: TokenSubTypeStructuredReference,
+	SubTypeMath:                TokenSubTypeMath,
+	SubTypeConcatenation:       TokenSubTypeConcatenation,
+	SubTypeIntersection:        TokenSubTypeIntersection,
+	SubTypeUnion:               TokenSubTypeUnion,
+}
+
+// String returns the token type string of the typed token type, such as
+// Operand.
+func (t TokenType) String() string {
+	if int(t) < len(tokenTypeNames) {
+		return tokenTypeNames[t]
+	}
+	return "TokenType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// ParseTokenType provides function to get the typed token type of a token
+// type string.
+func ParseTokenType(s string) (TokenType, error) {
+	for i, name := range tokenTypeNames {
+		if name == s {
+			return TokenType(i), nil
+		}
+	}
+	return TypeUnknown, fmt.Errorf("invalid token type %q", s)
+}
+
+// MarshalJSON returns the token type string as JSON.
+func (t TokenType) MarshalJSON() ([]byte, error) {
+	if int(t) >= len(tokenTypeNames) {
+		return nil, fmt.Errorf("invalid token type %d", t)
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON parses the token type string from JSON.
+func (t *TokenType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	typ, err := ParseTokenType(s)
+	if err != nil {
+		return err
+	}
+	*t = typ
+	return nil
+}
+
+// String returns the token subtype string of the typed token subtype, such
+// as Range, which is empty for SubTypeNone.
+func (t TokenSubType) String() string {
+	if int(t) < len(tokenSubTypeNames) {
+		return tokenSubTypeNames[t]
+	}
+	return "TokenSubType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// ParseTokenSubType provides function to get the typed token subtype of a
+// token subtype string.
+func ParseTokenSubType(s string) (TokenSubType, error) {
+	for i, name := range tokenSubTypeNames {
+		if name == s {
+			return TokenSubType(i), nil
+		}
+	}
+	return SubTypeNone, fmt.Errorf("invalid token subtype %q", s)
+}
+
+// MarshalJSON returns the token subtype string as JSON.
+func (t TokenSubType) MarshalJSON() ([]byte, error) {
+	if int(t) >= len(tokenSubTypeNames) {
+		return nil, fmt.Errorf("invalid token subtype %d", t)
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON parses the token subtype string from JSON.
+func (t *TokenSubType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	subType, err := ParseTokenSubType(s)
+	if err != nil {
+		return err
+	}
+	*t = subType
+	return nil
+}
+
+// Type returns the typed token type of the token, TypeUnknown for the
+// invalid token type strings.
+func (t Token) Type() TokenType {
+	switch t.TType {
+	case TokenTypeOperand:
+		return TypeOperand
+	case TokenTypeFunction:
+		return TypeFunction
+	case TokenTypeSubexpression:
+		return TypeSubexpression
+	case TokenTypeArgument:
+		return TypeArgument
+	case TokenTypeOperatorPrefix:
+		return TypeOperatorPrefix
+	case TokenTypeOperatorInfix:
+		return TypeOperatorInfix
+	case TokenTypeOperatorPostfix:
+		return TypeOperatorPostfix
+	case TokenTypeWhitespace:
+		return TypeWhitespace
+	case TokenTypeNoop:
+		return TypeNoop
+	}
+	return TypeUnknown
+}
+
+// SubType returns the typed token subtype of the token, SubTypeNone for the
+// empty and invalid token subtype strings.
+func (t Token) SubType() TokenSubType {
+	switch t.TSubType {
+	case TokenSubTypeStart:
+		return SubTypeStart
+	case TokenSubTypeStop:
+		return SubTypeStop
+	case TokenSubTypeText:
+		return SubTypeText
+	case TokenSubTypeNumber:
+		return SubTypeNumber
+	case TokenSubTypeLogical:
+		return SubTypeLogical
+	case TokenSubTypeError:
+		return SubTypeError
+	case TokenSubTypeRange:
+		return SubTypeRange
+	case TokenSubTypeName:
+		return SubTypeName
+	case TokenSubTypeStructuredReference:
+		return SubTypeStructuredReference
+	case TokenSubTypeMath:
+		return SubTypeMath
+	case TokenSubTypeConcatenation:
+		return SubTypeConcatenation
+	case TokenSubTypeIntersection:
+		return SubTypeIntersection
+	case TokenSubTypeUnion:
+		return SubTypeUnion
+	}
+	return SubTypeNone
+}
+
+// SetType provides function to set the token type of the token by the typed
+// token type.
+func (t *Token) SetType(typ TokenType) {
+	t.TType = typ.String()
+}
+
+// SetSubType provides function to set the token subtype of the token by the
+// typed token subtype.
+func (t *Token) SetSubType(subType TokenSubType) {
+	t.TSubType = subType.String()
+}
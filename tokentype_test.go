@@ -0,0 +1,80 @@
+package efp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTokenType(t *testing.T) {
+	for i := range tokenTypeNames {
+		typ := TokenType(i)
+		token := Token{TType: typ.String()}
+		if token.Type() != typ {
+			t.Errorf("expected %s, got %s", typ, token.Type())
+		}
+		if parsed, err := ParseTokenType(typ.String()); err != nil || parsed != typ {
+			t.Errorf("expected %s, got %s, %v", typ, parsed, err)
+		}
+	}
+	for i := range tokenSubTypeNames {
+		subType := TokenSubType(i)
+		token := Token{TSubType: subType.String()}
+		if token.SubType() != subType {
+			t.Errorf("expected %q, got %q", subType, token.SubType())
+		}
+		if parsed, err := ParseTokenSubType(subType.String()); err != nil || parsed != subType {
+			t.Errorf("expected %q, got %q, %v", subType, parsed, err)
+		}
+	}
+	if typ := (Token{TType: "Invalid"}).Type(); typ != TypeUnknown {
+		t.Errorf("expected Unknown, got %s", typ)
+	}
+	if _, err := ParseTokenType("Invalid"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := ParseTokenSubType("Invalid"); err == nil {
+		t.Error("expected error")
+	}
+	if s := TokenType(200).String(); s != "TokenType(200)" {
+		t.Errorf("expected TokenType(200), got %s", s)
+	}
+	if s := TokenSubType(200).String(); s != "TokenSubType(200)" {
+		t.Errorf("expected TokenSubType(200), got %s", s)
+	}
+	var token Token
+	token.SetType(TypeOperand)
+	token.SetSubType(SubTypeRange)
+	if token.TType != TokenTypeOperand || token.TSubType != TokenSubTypeRange {
+		t.Errorf("unexpected token %+v", token)
+	}
+	p := ExcelParser()
+	for _, token := range p.Parse(`=SUM(A1:B2,-1%)&" "&Table1[Qty]`) {
+		if token.Type().String() != token.TType || token.SubType().String() != token.TSubType {
+			t.Errorf("unexpected typed token of %+v", token)
+		}
+	}
+}
+
+func TestTokenTypeJSON(t *testing.T) {
+	type typed struct {
+		Type    TokenType
+		SubType TokenSubType
+	}
+	data, err := json.Marshal(typed{Type: TypeOperatorInfix, SubType: SubTypeConcatenation})
+	if err != nil || string(data) != `{"Type":"OperatorInfix","SubType":"Concatenation"}` {
+		t.Errorf("unexpected JSON %s, %v", data, err)
+	}
+	var actual typed
+	if err = json.Unmarshal(data, &actual); err != nil || actual.Type != TypeOperatorInfix || actual.SubType != SubTypeConcatenation {
+		t.Errorf("unexpected value %+v, %v", actual, err)
+	}
+	if err = json.Unmarshal([]byte(`{"Type":"Invalid"}`), &actual); err == nil {
+		t.Error("expected error")
+	}
+	if err = json.Unmarshal([]byte(`{"SubType":1}`), &actual); err == nil {
+		t.Error("expected error")
+	}
+	if _, err = json.Marshal(TokenSubType(200)); err == nil {
+		t.Error("expected error")
+	}
+}
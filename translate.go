@@ -0,0 +1,710 @@
+package efp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Languages of the localized function names.
+const (
+	LanguageEnglish    = "en"
+	LanguageGerman     = "de"
+	LanguageFrench     = "fr"
+	LanguageSpanish    = "es"
+	LanguageItalian    = "it"
+	LanguagePortuguese = "pt"
+	LanguageRussian    = "ru"
+	LanguageDutch      = "nl"
+)
+
+// translationLanguages is the order of the localized names in the function
+// translation table, Portuguese names are the Brazilian ones.
+var translationLanguages = []string{
+	LanguageGerman, LanguageFrench, LanguageSpanish, LanguageItalian,
+	LanguagePortuguese, LanguageRussian, LanguageDutch,
+}
+
+// functionTranslations maps the English function names to their localized
+// names in the order of the translation languages.
+var functionTranslations = map[string][7]string{
+	"ABS":                      {"ABS", "ABS", "ABS", "ASS", "ABS", "ABS", "ABS"},
+	"ACCRINT":                  {"AUFGELZINS", "INTERET.ACC", "INT.ACUM", "INT.MATURATO.PER", "JUROSACUM", "НАКОПДОХОД", "SAMENG.RENTE"},
+	"ACCRINTM":                 {"AUFGELZINSF", "INTERET.ACC.MAT", "INT.ACUM.V", "INT.MATURATO.SCAD", "JUROSACUMV", "НАКОПДОХОДПОГАШ", "SAMENG.RENTE.V"},
+	"ACOS":                     {"ARCCOS", "ACOS", "ACOS", "ARCCOS", "ACOS", "ACOS", "BOOGCOS"},
+	"ACOSH":                    {"ARCCOSHYP", "ACOSH", "ACOSH", "ARCCOSH", "ACOSH", "ACOSH", "BOOGCOSH"},
+	"ACOT":                     {"ARCCOT", "ACOT", "ACOT", "ARCCOT", "ACOT", "ACOT", "BOOGCOT"},
+	"ACOTH":                    {"ARCCOTHYP", "ACOTH", "ACOTH", "ARCCOTH", "ACOTH", "ACOTH", "BOOGCOTH"},
+	"ADDRESS":                  {"ADRESSE", "ADRESSE", "DIRECCION", "INDIRIZZO", "ENDEREÇO", "АДРЕС", "ADRES"},
+	"AGGREGATE":                {"AGGREGAT", "AGREGAT", "AGREGAR", "AGGREGA", "AGREGAR", "АГРЕГАТ", "AGGREGAAT"},
+	"AMORDEGRC":                {"AMORDEGRK", "AMORDEGRC", "AMORTIZ.PROGRE", "AMMORT.DEGR", "AMORDEGRC", "АМОРУМ", "AMORDEGRC"},
+	"AMORLINC":                 {"AMORLINEARK", "AMORLINC", "AMORTIZ.LIN", "AMMORT.PER", "AMORLINC", "АМОРУВ", "AMORLINC"},
+	"ANCHORARRAY":              {"ANCHORARRAY", "ANCHORARRAY", "ANCHORARRAY", "ANCHORARRAY", "ANCHORARRAY", "ANCHORARRAY", "ANCHORARRAY"},
+	"AND":                      {"UND", "ET", "Y", "E", "E", "И", "EN"},
+	"ARABIC":                   {"ARABISCH", "CHIFFRE.ARABE", "NUMERO.ARABE", "ARABO", "ARÁBICO", "АРАБСКОЕ", "ARABISCH"},
+	"AREAS":                    {"BEREICHE", "ZONES", "AREAS", "AREE", "ÁREAS", "ОБЛАСТИ", "BEREIKEN"},
+	"ARRAYTOTEXT":              {"ARRAYTOTEXT", "TABLEAU.EN.TEXTE", "MATRIZATEXTO", "MATRICE.A.TESTO", "MATRIZPARATEXTO", "МАССИВВТЕКСТ", "MATRIX.NAAR.TEKST"},
+	"ASC":                      {"ASC", "ASC", "ASC", "ASC", "ASC", "ASC", "ASC"},
+	"ASIN":                     {"ARCSIN", "ASIN", "ASENO", "ARCSEN", "ASEN", "ASIN", "BOOGSIN"},
+	"ASINH":                    {"ARCSINHYP", "ASINH", "ASENOH", "ARCSENH", "ASENH", "ASINH", "BOOGSINH"},
+	"ATAN":                     {"ARCTAN", "ATAN", "ATAN", "ARCTAN", "ATAN", "ATAN", "BOOGTAN"},
+	"ATAN2":                    {"ARCTAN2", "ATAN2", "ATAN2", "ARCTAN.2", "ATAN2", "ATAN2", "BOOGTAN2"},
+	"ATANH":                    {"ARCTANHYP", "ATANH", "ATANH", "ARCTANH", "ATANH", "ATANH", "BOOGTANH"},
+	"AVEDEV":                   {"MITTELABW", "ECART.MOYEN", "DESVPROM", "MEDIA.DEV", "DESV.MÉDIO", "СРОТКЛ", "GEM.DEVIATIE"},
+	"AVERAGE":                  {"MITTELWERT", "MOYENNE", "PROMEDIO", "MEDIA", "MÉDIA", "СРЗНАЧ", "GEMIDDELDE"},
+	"AVERAGEA":                 {"MITTELWERTA", "AVERAGEA", "PROMEDIOA", "MEDIA.VALORI", "MÉDIAA", "СРЗНАЧА", "GEMIDDELDEA"},
+	"AVERAGEIF":                {"MITTELWERTWENN", "MOYENNE.SI", "PROMEDIO.SI", "MEDIA.SE", "MÉDIASE", "СРЗНАЧЕСЛИ", "GEMIDDELDE.ALS"},
+	"AVERAGEIFS":               {"MITTELWERTWENNS", "MOYENNE.SI.ENS", "PROMEDIO.SI.CONJUNTO", "MEDIA.PIÙ.SE", "MÉDIASES", "СРЗНАЧЕСЛИМН", "GEMIDDELDEN.ALS"},
+	"BAHTTEXT":                 {"BAHTTEXT", "BAHTTEXT", "TEXTOBAHT", "BAHTTESTO", "BAHTTEXT", "БАТТЕКСТ", "BAHT.TEKST"},
+	"BASE":                     {"BASIS", "BASE", "BASE", "BASE", "BASE", "ОСНОВАНИЕ", "BASIS"},
+	"BESSELI":                  {"BESSELI", "BESSELI", "BESSELI", "BESSEL.I", "BESSELI", "БЕССЕЛЬ.I", "BESSEL.I"},
+	"BESSELJ":                  {"BESSELJ", "BESSELJ", "BESSELJ", "BESSEL.J", "BESSELJ", "БЕССЕЛЬ.J", "BESSEL.J"},
+	"BESSELK":                  {"BESSELK", "BESSELK", "BESSELK", "BESSEL.K", "BESSELK", "БЕССЕЛЬ.K", "BESSEL.K"},
+	"BESSELY":                  {"BESSELY", "BESSELY", "BESSELY", "BESSEL.Y", "BESSELY", "БЕССЕЛЬ.Y", "BESSEL.Y"},
+	"BETA.DIST":                {"BETA.VERT", "LOI.BETA.N", "DISTR.BETA.N", "DISTRIB.BETA.N", "DIST.BETA", "БЕТА.РАСП", "BETA.VERD"},
+	"BETA.INV":                 {"BETA.INV", "BETA.INVERSE.N", "INV.BETA.N", "INV.BETA.N", "INV.BETA", "БЕТА.ОБР", "BETA.INV"},
+	"BETADIST":                 {"BETAVERT", "LOI.BETA", "DISTR.BETA", "DISTRIB.BETA", "DISTBETA", "БЕТАРАСП", "BETAVERD"},
+	"BETAINV":                  {"BETAINV", "BETA.INVERSE", "DISTR.BETA.INV", "INV.BETA", "BETA.ACUM.INV", "БЕТАОБР", "BETAINV"},
+	"BIN2DEC":                  {"BININDEZ", "BINDEC", "BIN.A.DEC", "BINARIO.DECIMALE", "BINADEC", "ДВ.В.ДЕС", "BIN.N.DEC"},
+	"BIN2HEX":                  {"BININHEX", "BINHEX", "BIN.A.HEX", "BINARIO.HEX", "BINAHEX", "ДВ.В.ШЕСТН", "BIN.N.HEX"},
+	"BIN2OCT":                  {"BININOKT", "BINOCT", "BIN.A.OCT", "BINARIO.OCT", "BINAOCT", "ДВ.В.ВОСЬМ", "BIN.N.OCT"},
+	"BINOM.DIST":               {"BINOM.VERT", "LOI.BINOMIALE.N", "DISTR.BINOM.N", "DISTRIB.BINOM.N", "DISTR.BINOM", "БИНОМ.РАСП", "BINOM.VERD"},
+	"BINOM.DIST.RANGE":         {"BINOM.VERT.BEREICH", "LOI.BINOMIALE.SERIE", "DISTR.BINOM.SERIE", "INTERVALLO.DISTRIB.BINOM.N.", "INTERV.DISTR.BINOM", "БИНОМ.РАСП.ДИАП", "BINOM.VERD.BEREIK"},
+	"BINOM.INV":                {"BINOM.INV", "LOI.BINOMIALE.INVERSE", "INV.BINOM", "INV.BINOM", "INV.BINOM", "БИНОМ.ОБР", "BINOM.INV"},
+	"BINOMDIST":                {"BINOMVERT", "LOI.BINOMIALE", "DISTR.BINOM", "DISTRIB.BINOM", "DISTRBINOM", "БИНОМРАСП", "BINOMIALE.VERD"},
+	"BITAND":                   {"BITUND", "BITET", "BIT.Y", "BITAND", "BITAND", "БИТ.И", "BIT.EN"},
+	"BITLSHIFT":                {"BITLVERSCHIEB", "BITDECALG", "BIT.DESPLIZQDA", "BIT.SPOSTA.SX", "DESLOCESQBIT", "БИТ.СДВИГЛ", "BIT.VERSCHUIF.LINKS"},
+	"BITOR":                    {"BITODER", "BITOU", "BIT.O", "BITOR", "BITOR", "БИТ.ИЛИ", "BIT.OF"},
+	"BITRSHIFT":                {"BITRVERSCHIEB", "BITDECALD", "BIT.DESPLDCHA", "BIT.SPOSTA.DX", "DESLOCDIRBIT", "БИТ.СДВИГП", "BIT.VERSCHUIF.RECHTS"},
+	"BITXOR":                   {"BITXODER", "BITOUEXCLUSIF", "BIT.XO", "BITXOR", "BITXOR", "БИТ.ИСКЛИЛИ", "BIT.EX.OF"},
+	"BYCOL":                    {"NACHSPALTE", "BYCOL", "BYCOL", "BYCOL", "BYCOL", "BYCOL", "BYCOL"},
+	"BYROW":                    {"NACHZEILE", "BYROW", "BYROW", "BYROW", "BYROW", "BYROW", "BYROW"},
+	"CEILING":                  {"OBERGRENZE", "PLAFOND", "MULTIPLO.SUPERIOR", "ARROTONDA.ECCESSO", "TETO", "ОКРВВЕРХ", "AFRONDEN.BOVEN"},
+	"CEILING.MATH":             {"OBERGRENZE.MATHEMATIK", "PLAFOND.MATH", "MULTIPLO.SUPERIOR.MAT", "ARROTONDA.ECCESSO.MAT", "TETO.MAT", "ОКРВВЕРХ.МАТ", "AFRONDEN.BOVEN.WISK"},
+	"CEILING.PRECISE":          {"OBERGRENZE.GENAU", "PLAFOND.PRECIS", "MULTIPLO.SUPERIOR.EXACTO", "ARROTONDA.ECCESSO.PRECISA", "TETO.PRECISO", "ОКРВВЕРХ.ТОЧН", "PLAFOND.NAUWKEURIG"},
+	"CELL":                     {"ZELLE", "CELLULE", "CELDA", "CELLA", "CÉL", "ЯЧЕЙКА", "CEL"},
+	"CHAR":                     {"ZEICHEN", "CAR", "CARACTER", "CODICE.CARATT", "CARACT", "СИМВОЛ", "TEKEN"},
+	"CHIDIST":                  {"CHIVERT", "LOI.KHIDEUX", "DISTR.CHI", "DISTRIB.CHI", "DIST.QUI", "ХИ2РАСП", "CHI.KWADRAAT"},
+	"CHIINV":                   {"CHIINV", "KHIDEUX.INVERSE", "PRUEBA.CHI.INV", "INV.CHI", "INV.QUI", "ХИ2ОБР", "CHI.KWADRAAT.INV"},
+	"CHISQ.DIST":               {"CHIQU.VERT", "LOI.KHIDEUX.N", "DISTR.CHICUAD", "DISTRIB.CHI.QUAD", "DIST.QUIQUA", "ХИ2.РАСП", "CHIKW.VERD"},
+	"CHISQ.DIST.RT":            {"CHIQU.VERT.RE", "LOI.KHIDEUX.DROITE", "DISTR.CHICUAD.CD", "DISTRIB.CHI.QUAD.DS", "DIST.QUIQUA.CD", "ХИ2.РАСП.ПХ", "CHIKW.VERD.RECHTS"},
+	"CHISQ.INV":                {"CHIQU.INV", "LOI.KHIDEUX.INVERSE", "INV.CHICUAD", "INV.CHI.QUAD", "INV.QUIQUA", "ХИ2.ОБР", "CHIKW.INV"},
+	"CHISQ.INV.RT":             {"CHIQU.INV.RE", "LOI.KHIDEUX.INVERSE.DROITE", "INV.CHICUAD.CD", "INV.CHI.QUAD.DS", "INV.QUIQUA.CD", "ХИ2.ОБР.ПХ", "CHIKW.INV.RECHTS"},
+	"CHISQ.TEST":               {"CHIQU.TEST", "CHISQ.TEST", "PRUEBA.CHICUAD", "TEST.CHI.QUAD", "TESTE.QUIQUA", "ХИ2.ТЕСТ", "CHIKW.TEST"},
+	"CHITEST":                  {"CHITEST", "TEST.KHIDEUX", "PRUEBA.CHI", "TEST.CHI", "TESTE.QUI", "ХИ2ТЕСТ", "CHI.TOETS"},
+	"CHOOSE":                   {"WAHL", "CHOISIR", "ELEGIR", "SCEGLI", "ESCOLHER", "ВЫБОР", "KIEZEN"},
+	"CHOOSECOLS":               {"SPALTENWAHL", "CHOISIRCOLS", "ELEGIRCOLS", "SCEGLI.COL", "ESCOLHERCOLS", "ВЫБОРСТОЛБЦ", "KIES.KOLOMMEN"},
+	"CHOOSEROWS":               {"ZEILENWAHL", "CHOISIRLIGNES", "ELEGIRFILAS", "SCEGLI.RIGA", "ESCOLHERLINS", "ВЫБОРСТРОК", "KIES.RIJEN"},
+	"CLEAN":                    {"SÄUBERN", "EPURAGE", "LIMPIAR", "LIBERA", "TIRAR", "ПЕЧСИМВ", "WISSEN.CONTROL"},
+	"CODE":                     {"CODE", "CODE", "CODIGO", "CODICE", "CÓDIGO", "КОДСИМВ", "CODE"},
+	"COLUMN":                   {"SPALTE", "COLONNE", "COLUMNA", "RIF.COLONNA", "COL", "СТОЛБЕЦ", "KOLOM"},
+	"COLUMNS":                  {"SPALTEN", "COLONNES", "COLUMNAS", "COLONNE", "COLS", "ЧИСЛСТОЛБ", "KOLOMMEN"},
+	"COMBIN":                   {"KOMBINATIONEN", "COMBIN", "COMBINAT", "COMBINAZIONE", "COMBIN", "ЧИСЛКОМБ", "COMBINATIES"},
+	"COMBINA":                  {"KOMBINATIONEN2", "COMBINA", "COMBINA", "COMBINAZIONE.VALORI", "COMBINA", "ЧИСЛКОМБА", "COMBIN.A"},
+	"COMPLEX":                  {"KOMPLEXE", "COMPLEXE", "COMPLEJO", "COMPLESSO", "COMPLEXO", "КОМПЛЕКСН", "COMPLEX"},
+	"CONCAT":                   {"TEXTKETTE", "CONCAT", "CONCAT", "CONCAT", "CONCAT", "СЦЕП", "TEKST.SAMENV"},
+	"CONCATENATE":              {"VERKETTEN", "CONCATENER", "CONCATENAR", "CONCATENA", "CONCATENAR", "СЦЕПИТЬ", "TEKST.SAMENVOEGEN"},
+	"CONFIDENCE":               {"KONFIDENZ", "INTERVALLE.CONFIANCE", "INTERVALO.CONFIANZA", "CONFIDENZA", "INT.CONFIANÇA", "ДОВЕРИТ", "BETROUWBAARHEID"},
+	"CONFIDENCE.NORM":          {"KONFIDENZ.NORM", "INTERVALLE.CONFIANCE.NORMAL", "INTERVALO.CONFIANZA.NORM", "CONFIDENZA.NORM", "INT.CONFIANÇA.NORM", "ДОВЕРИТ.НОРМ", "BETROUWBAARHEID.NORM"},
+	"CONFIDENCE.T":             {"KONFIDENZ.T", "INTERVALLE.CONFIANCE.STUDENT", "INTERVALO.CONFIANZA.T", "CONFIDENZA.T", "INT.CONFIANÇA.T", "ДОВЕРИТ.СТЬЮДЕНТ", "BETROUWBAARHEID.T"},
+	"CONVERT":                  {"UMWANDELN", "CONVERT", "CONVERTIR", "CONVERTI", "CONVERTER", "ПРЕОБР", "CONVERTEREN"},
+	"CORREL":                   {"KORREL", "COEFFICIENT.CORRELATION", "COEF.DE.CORREL", "CORRELAZIONE", "CORREL", "КОРРЕЛ", "CORRELATIE"},
+	"COS":                      {"COS", "COS", "COS", "COS", "COS", "COS", "COS"},
+	"COSH":                     {"COSHYP", "COSH", "COSH", "COSH", "COSH", "COSH", "COSH"},
+	"COT":                      {"COT", "COT", "COT", "COT", "COT", "COT", "COT"},
+	"COTH":                     {"COTHYP", "COTH", "COTH", "COTH", "COTH", "COTH", "COTH"},
+	"COUNT":                    {"ANZAHL", "NB", "CONTAR", "CONTA.NUMERI", "CONT.NÚM", "СЧЁТ", "AANTAL"},
+	"COUNTA":                   {"ANZAHL2", "NBVAL", "CONTARA", "CONTA.VALORI", "CONT.VALORES", "СЧЁТЗ", "AANTALARG"},
+	"COUNTBLANK":               {"ANZAHLLEEREZELLEN", "NB.VIDE", "CONTAR.BLANCO", "CONTA.VUOTE", "CONTAR.VAZIO", "СЧИТАТЬПУСТОТЫ", "AANTAL.LEGE.CELLEN"},
+	"COUNTIF":                  {"ZÄHLENWENN", "NB.SI", "CONTAR.SI", "CONTA.SE", "CONT.SE", "СЧЁТЕСЛИ", "AANTAL.ALS"},
+	"COUNTIFS":                 {"ZÄHLENWENNS", "NB.SI.ENS", "CONTAR.SI.CONJUNTO", "CONTA.PIÙ.SE", "CONT.SES", "СЧЁТЕСЛИМН", "AANTALLEN.ALS"},
+	"COUPDAYBS":                {"ZINSTERMTAGVA", "NB.JOURS.COUPON.PREC", "CUPON.DIAS.L1", "GIORNI.CED.INIZ.LIQ", "CUPDIASINLIQ", "ДНЕЙКУПОНДО", "COUP.DAGEN.BB"},
+	"COUPDAYS":                 {"ZINSTERMTAGE", "NB.JOURS.COUPONS", "CUPON.DIAS", "GIORNI.CED", "CUPDIAS", "ДНЕЙКУПОН", "COUP.DAGEN"},
+	"COUPDAYSNC":               {"ZINSTERMTAGNZ", "NB.JOURS.COUPON.SUIV", "CUPON.DIAS.L2", "GIORNI.CED.NUOVA", "CUPDIASPRÓX", "ДНЕЙКУПОНПОСЛЕ", "COUP.DAGEN.VV"},
+	"COUPNCD":                  {"ZINSTERMNZ", "DATE.COUPON.SUIV", "CUPON.FECHA.L2", "DATA.CED.SUCC", "CUPDATAPRÓX", "ДАТАКУПОНПОСЛЕ", "COUP.DATUM.NB"},
+	"COUPNUM":                  {"ZINSTERMZAHL", "NB.COUPONS", "CUPON.NUM", "NUM.CED", "CUPNÚM", "ЧИСЛКУПОН", "COUP.AANTAL"},
+	"COUPPCD":                  {"ZINSTERMVZ", "DATE.COUPON.PREC", "CUPON.FECHA.L1", "DATA.CED.PREC", "CUPDATAANT", "ДАТАКУПОНДО", "COUP.DATUM.VB"},
+	"COVAR":                    {"KOVAR", "COVARIANCE", "COVAR", "COVARIANZA", "COVAR", "КОВАР", "COVARIANTIE"},
+	"COVARIANCE.P":             {"KOVARIANZ.P", "COVARIANCE.PEARSON", "COVARIANCE.P", "COVARIANZA.P", "COVARIAÇÃO.P", "КОВАРИАЦИЯ.Г", "COVARIANTIE.P"},
+	"COVARIANCE.S":             {"KOVARIANZ.S", "COVARIANCE.STANDARD", "COVARIANZA.M", "COVARIANZA.C", "COVARIAÇÃO.S", "КОВАРИАЦИЯ.В", "COVARIANTIE.S"},
+	"CRITBINOM":                {"KRITBINOM", "CRITERE.LOI.BINOMIALE", "BINOM.CRIT", "CRIT.BINOM", "CRIT.BINOM", "КРИТБИНОМ", "CRIT.BINOM"},
+	"CSC":                      {"COSEC", "CSC", "CSC", "CSC", "CSC", "CSC", "COSEC"},
+	"CSCH":                     {"COSECHYP", "CSCH", "CSCH", "CSCH", "CSCH", "CSCH", "COSECH"},
+	"CUBEKPIMEMBER":            {"CUBEKPIELEMENT", "MEMBREKPICUBE", "MIEMBROKPICUBO", "MEMBRO.KPI.CUBO", "MEMBROKPICUBO", "КУБЭЛЕМЕНТКИП", "KUBUSKPILID"},
+	"CUBEMEMBER":               {"CUBEELEMENT", "MEMBRECUBE", "MIEMBROCUBO", "MEMBRO.CUBO", "MEMBROCUBO", "КУБЭЛЕМЕНТ", "KUBUSLID"},
+	"CUBEMEMBERPROPERTY":       {"CUBEELEMENTEIGENSCHAFT", "PROPRIETEMEMBRECUBE", "PROPIEDADMIEMBROCUBO", "PROPRIETÀ.MEMBRO.CUBO", "PROPRIEDADEMEMBROCUBO", "КУБСВОЙСТВОЭЛЕМЕНТА", "KUBUSLIDEIGENSCHAP"},
+	"CUBERANKEDMEMBER":         {"CUBERANGELEMENT", "RANGMEMBRECUBE", "MIEMBRORANGOCUBO", "MEMBRO.CUBO.CON.RANGO", "MEMBROCLASSIFICADOCUBO", "КУБПОРЭЛЕМЕНТ", "KUBUSGERANGSCHIKTLID"},
+	"CUBESET":                  {"CUBEMENGE", "JEUCUBE", "CONJUNTOCUBO", "SET.CUBO", "CONJUNTOCUBO", "КУБМНОЖ", "KUBUSSET"},
+	"CUBESETCOUNT":             {"CUBEMENGENANZAHL", "NBJEUCUBE", "RECUENTOCONJUNTOCUBO", "CONTA.SET.CUBO", "CONTAGEMCONJUNTOCUBO", "КУБЧИСЛОЭЛМНОЖ", "KUBUSSETAANTAL"},
+	"CUBEVALUE":                {"CUBEWERT", "VALEURCUBE", "VALORCUBO", "VALORE.CUBO", "VALORCUBO", "КУБЗНАЧЕНИЕ", "KUBUSWAARDE"},
+	"CUMIPMT":                  {"KUMZINSZ", "CUMUL.INTER", "PAGO.INT.ENTRE", "INT.CUMUL", "PGTOJURACUM", "ОБЩПЛАТ", "CUM.RENTE"},
+	"CUMPRINC":                 {"KUMKAPITAL", "CUMUL.PRINCPER", "PAGO.PRINC.ENTRE", "CAP.CUM", "PGTOCAPACUM", "ОБЩДОХОД", "CUM.HOOFDSOM"},
+	"DATE":                     {"DATUM", "DATE", "FECHA", "DATA", "DATA", "ДАТА", "DATUM"},
+	"DATEDIF":                  {"DATEDIF", "DATEDIF", "SIFECHA", "DATA.DIFF", "DATADIF", "РАЗНДАТ", "DATUMVERSCHIL"},
+	"DATEVALUE":                {"DATWERT", "DATEVAL", "FECHANUMERO", "DATA.VALORE", "DATA.VALOR", "ДАТАЗНАЧ", "DATUMWAARDE"},
+	"DAVERAGE":                 {"DBMITTELWERT", "BDMOYENNE", "BDPROMEDIO", "DB.MEDIA", "BDMÉDIA", "ДСРЗНАЧ", "DBGEMIDDELDE"},
+	"DAY":                      {"TAG", "JOUR", "DIA", "GIORNO", "DIA", "ДЕНЬ", "DAG"},
+	"DAYS":                     {"TAGE", "JOURS", "DIAS", "GIORNI", "DIAS", "ДНИ", "DAGEN"},
+	"DAYS360":                  {"TAGE360", "JOURS360", "DIAS360", "GIORNO360", "DIAS360", "ДНЕЙ360", "DAGEN360"},
+	"DB":                       {"GDA2", "DB", "DB", "AMMORT.FISSO", "BD", "ФУО", "DB"},
+	"DBCS":                     {"DBCS", "DBCS", "DBCS", "DBCS", "DBCS", "DBCS", "DBCS"},
+	"DCOUNT":                   {"DBANZAHL", "BDNB", "BDCONTAR", "DB.CONTA.NUMERI", "BDCONTAR", "БСЧЁТ", "DBAANTAL"},
+	"DCOUNTA":                  {"DBANZAHL2", "BDNBVAL", "BDCONTARA", "DB.CONTA.VALORI", "BDCONTARA", "БСЧЁТА", "DBAANTALC"},
+	"DDB":                      {"GDA", "DDB", "DDB", "AMMORT", "BDD", "ДДОБ", "DDB"},
+	"DEC2BIN":                  {"DEZINBIN", "DECBIN", "DEC.A.BIN", "DECIMALE.BINARIO", "DECABIN", "ДЕС.В.ДВ", "DEC.N.BIN"},
+	"DEC2HEX":                  {"DEZINHEX", "DECHEX", "DEC.A.HEX", "DECIMALE.HEX", "DECAHEX", "ДЕС.В.ШЕСТН", "DEC.N.HEX"},
+	"DEC2OCT":                  {"DEZINOKT", "DECOCT", "DEC.A.OCT", "DECIMALE.OCT", "DECAOCT", "ДЕС.В.ВОСЬМ", "DEC.N.OCT"},
+	"DECIMAL":                  {"DEZIMAL", "DECIMAL", "CONV.DECIMAL", "DECIMALE", "DECIMAL", "ДЕС", "DECIMAAL"},
+	"DEGREES":                  {"GRAD", "DEGRES", "GRADOS", "GRADI", "GRAUS", "ГРАДУСЫ", "GRADEN"},
+	"DELTA":                    {"DELTA", "DELTA", "DELTA", "DELTA", "DELTA", "ДЕЛЬТА", "DELTA"},
+	"DETECTLANGUAGE":           {"DETECTLANGUAGE", "DETECTLANGUAGE", "DETECTLANGUAGE", "DETECTLANGUAGE", "DETECTLANGUAGE", "DETECTLANGUAGE", "DETECTLANGUAGE"},
+	"DEVSQ":                    {"SUMQUADABW", "SOMME.CARRES.ECARTS", "DESVIA2", "DEV.Q", "DESVQ", "КВАДРОТКЛ", "DEV.KWAD"},
+	"DGET":                     {"DBAUSZUG", "BDLIRE", "BDEXTRAER", "DB.VALORI", "BDEXTRAIR", "БИЗВЛЕЧЬ", "DBLEZEN"},
+	"DISC":                     {"DISAGIO", "TAUX.ESCOMPTE", "TASA.DESC", "TASSO.SCONTO", "DESC", "СКИДКА", "DISCONTO"},
+	"DMAX":                     {"DBMAX", "BDMAX", "BDMAX", "DB.MAX", "BDMÁX", "ДМАКС", "DBMAX"},
+	"DMIN":                     {"DBMIN", "BDMIN", "BDMIN", "DB.MIN", "BDMÍN", "ДМИН", "DBMIN"},
+	"DOLLAR":                   {"DM", "DEVISE", "MONEDA", "VALUTA", "MOEDA", "РУБЛЬ", "EURO"},
+	"DOLLARDE":                 {"NOTIERUNGDEZ", "PRIX.DEC", "MONEDA.DEC", "VALUTA.DEC", "MOEDADEC", "РУБЛЬ.ДЕС", "EURO.DE"},
+	"DOLLARFR":                 {"NOTIERUNGBRU", "PRIX.FRAC", "MONEDA.FRAC", "VALUTA.FRAZ", "MOEDAFRA", "РУБЛЬ.ДРОБЬ", "EURO.BR"},
+	"DPRODUCT":                 {"DBPRODUKT", "BDPRODUIT", "BDPRODUCTO", "DB.PRODOTTO", "BDMULTIPL", "БДПРОИЗВЕД", "DBPRODUCT"},
+	"DROP":                     {"WEGLASSEN", "EXCLURE", "EXCLUIR", "ESCLUDI", "DESCARTAR", "СБРОСИТЬ", "NEERZETTEN"},
+	"DSTDEV":                   {"DBSTDABW", "BDECARTYPE", "BDDESVEST", "DB.DEV.ST", "BDEST", "ДСТАНДОТКЛ", "DBSTDEV"},
+	"DSTDEVP":                  {"DBSTDABWN", "BDECARTYPEP", "BDDESVESTP", "DB.DEV.ST.POP", "BDDESVPA", "ДСТАНДОТКЛП", "DBSTDEVP"},
+	"DSUM":                     {"DBSUMME", "BDSOMME", "BDSUMA", "DB.SOMMA", "BDSOMA", "БДСУММ", "DBSOM"},
+	"DURATION":                 {"DURATION", "DUREE", "DURACION", "DURATA", "DURAÇÃO", "ДЛИТ", "DUUR"},
+	"DVAR":                     {"DBVARIANZ", "BDVAR", "BDVAR", "DB.VAR", "BDVAREST", "БДДИСП", "DBVAR"},
+	"DVARP":                    {"DBVARIANZEN", "BDVARP", "BDVARP", "DB.VAR.POP", "BDVARP", "БДДИСПП", "DBVARP"},
+	"EDATE":                    {"EDATUM", "MOIS.DECALER", "FECHA.MES", "DATA.MESE", "DATAM", "ДАТАМЕС", "ZELFDE.DAG"},
+	"EFFECT":                   {"EFFEKTIV", "TAUX.EFFECTIF", "INT.EFECTIVO", "EFFETTIVO", "EFETIVA", "ЭФФЕКТ", "EFFECT.RENTE"},
+	"ENCODEURL":                {"URLCODIEREN", "URLENCODAGE", "URLCODIF", "CODIFICA.URL", "CODIFURL", "КОДИР.URL", "URL.CODEREN"},
+	"EOMONTH":                  {"MONATSENDE", "FIN.MOIS", "FIN.MES", "FINE.MESE", "FIMMÊS", "КОНМЕСЯЦА", "LAATSTE.DAG"},
+	"ERF":                      {"GAUSSFEHLER", "ERF", "FUN.ERROR", "FUNZ.ERRORE", "FUNERRO", "ФОШ", "FOUTFUNCTIE"},
+	"ERF.PRECISE":              {"GAUSSF.GENAU", "ERF.PRECIS", "FUN.ERROR.EXACTO", "FUNZ.ERRORE.PRECISA", "FUNERRO.PRECISO", "ФОШ.ТОЧН", "FOUTFUNCTIE.NAUWKEURIG"},
+	"ERFC":                     {"GAUSSFKOMPL", "ERFC", "FUN.ERROR.COMPL", "FUNZ.ERRORE.COMP", "FUNERROCOMPL", "ДФОШ", "FOUT.COMPLEMENT"},
+	"ERFC.PRECISE":             {"GAUSSFKOMPL.GENAU", "ERFC.PRECIS", "FUN.ERROR.COMPL.EXACTO", "FUNZ.ERRORE.COMP.PRECISA", "FUNERROCOMPL.PRECISO", "ДФОШ.ТОЧН", "FOUT.COMPLEMENT.NAUWKEURIG"},
+	"ERROR.TYPE":               {"FEHLER.TYP", "TYPE.ERREUR", "TIPO.DE.ERROR", "ERRORE.TIPO", "TIPO.ERRO", "ТИП.ОШИБКИ", "TYPE.FOUT"},
+	"EVEN":                     {"GERADE", "PAIR", "REDONDEA.PAR", "PARI", "PAR", "ЧЁТН", "EVEN"},
+	"EXACT":                    {"IDENTISCH", "EXACT", "IGUAL", "IDENTICO", "EXATO", "СОВПАД", "GELIJK"},
+	"EXP":                      {"EXP", "EXP", "EXP", "EXP", "EXP", "EXP", "EXP"},
+	"EXPAND":                   {"ERWEITERN", "DEVELOPPER", "EXPANDIR", "ESPANDI", "EXPANDIR", "РАЗВЕРНУТЬ", "UITBREIDEN"},
+	"EXPON.DIST":               {"EXPON.VERT", "LOI.EXPONENTIELLE.N", "DISTR.EXP.N", "DISTRIB.EXP.N", "DIST.EXPON", "ЭКСП.РАСП", "EXPON.VERD.N"},
+	"EXPONDIST":                {"EXPONVERT", "LOI.EXPONENTIELLE", "DISTR.EXP", "DISTRIB.EXP", "DISTEXPON", "ЭКСПРАСП", "EXPON.VERD"},
+	"F.DIST":                   {"F.VERT", "LOI.F.N", "DISTR.F.N", "DISTRIBF", "DIST.F", "F.РАСП", "F.VERD"},
+	"F.DIST.RT":                {"F.VERT.RE", "LOI.F.DROITE", "DISTR.F.CD", "DISTRIB.F.DS", "DIST.F.CD", "F.РАСП.ПХ", "F.VERD.RECHTS"},
+	"F.INV":                    {"F.INV", "INVERSE.LOI.F.N", "INV.F", "INVF", "INV.F", "F.ОБР", "F.INV"},
+	"F.INV.RT":                 {"F.INV.RE", "INVERSE.LOI.F.DROITE", "INV.F.CD", "INV.F.DS", "INV.F.CD", "F.ОБР.ПХ", "F.INV.RECHTS"},
+	"F.TEST":                   {"F.TEST", "F.TEST", "PRUEBA.F.N", "TESTF", "TESTE.F", "F.ТЕСТ", "F.TEST"},
+	"FACT":                     {"FAKULTÄT", "FACT", "FACT", "FATTORIALE", "FATORIAL", "ФАКТР", "FACULTEIT"},
+	"FACTDOUBLE":               {"ZWEIFAKULTÄT", "FACTDOUBLE", "FACT.DOBLE", "FATT.DOPPIO", "FATDUPLO", "ДВФАКТР", "DUBBELE.FACULTEIT"},
+	"FALSE":                    {"FALSCH", "FAUX", "FALSO", "FALSO", "FALSO", "ЛОЖЬ", "ONWAAR"},
+	"FDIST":                    {"FVERT", "LOI.F", "DISTR.F", "DISTRIB.F", "DISTF", "FРАСП", "F.VERDELING"},
+	"FIELDVALUE":               {"FIELDVALUE", "FIELDVALUE", "FIELDVALUE", "FIELDVALUE", "FIELDVALUE", "FIELDVALUE", "FIELDVALUE"},
+	"FILTER":                   {"FILTER", "FILTRE", "FILTRAR", "FILTRO", "FILTRO", "ФИЛЬТР", "FILTER"},
+	"FILTERXML":                {"FILTERXML", "FILTRE.XML", "XMLFILTRO", "FILTRO.XML", "FILTRARXML", "ФИЛЬТР.XML", "XML.FILTEREN"},
+	"FIND":                     {"FINDEN", "TROUVE", "ENCONTRAR", "TROVA", "PROCURAR", "НАЙТИ", "VIND.ALLES"},
+	"FINDB":                    {"FINDENB", "TROUVERB", "ENCONTRARB", "TROVA.B", "PROCURARB", "НАЙТИБ", "VIND.ALLES.B"},
+	"FINV":                     {"FINV", "INVERSE.LOI.F", "DISTR.F.INV", "INV.F", "INVF", "FРАСПОБР", "F.INVERSE"},
+	"FISHER":                   {"FISHER", "FISHER", "FISHER", "FISHER", "FISHER", "ФИШЕР", "FISHER"},
+	"FISHERINV":                {"FISHERINV", "FISHER.INVERSE", "PRUEBA.FISHER.INV", "INV.FISHER", "FISHERINV", "ФИШЕРОБР", "FISHER.INV"},
+	"FIXED":                    {"FEST", "CTXT", "DECIMAL", "FISSO", "DEF.NÚM.DEC", "ФИКСИРОВАННЫЙ", "VAST"},
+	"FLOOR":                    {"UNTERGRENZE", "PLANCHER", "MULTIPLO.INFERIOR", "ARROTONDA.DIFETTO", "ARREDMULTB", "ОКРВНИЗ", "AFRONDEN.BENEDEN"},
+	"FLOOR.MATH":               {"UNTERGRENZE.MATHEMATIK", "PLANCHER.MATH", "MULTIPLO.INFERIOR.MAT", "ARROTONDA.DIFETTO.MAT", "ARREDMULTB.MAT", "ОКРВНИЗ.МАТ", "AFRONDEN.BENEDEN.WISK"},
+	"FLOOR.PRECISE":            {"UNTERGRENZE.GENAU", "PLANCHER.PRECIS", "MULTIPLO.INFERIOR.EXACTO", "ARROTONDA.DIFETTO.PRECISA", "ARREDMULTB.PRECISO", "ОКРВНИЗ.ТОЧН", "AFRONDEN.BENEDEN.NAUWKEURIG"},
+	"FORECAST":                 {"PROGNOSE", "PREVISION", "PRONOSTICO", "PREVISIONE", "PREVISÃO", "ПРЕДСКАЗ", "VOORSPELLEN"},
+	"FORECAST.ETS":             {"PROGNOSE.ETS", "PREVISION.ETS", "PRONOSTICO.ETS", "PREVISIONE.ETS", "PREVISÃO.ETS", "ПРЕДСКАЗ.ETS", "VOORSPELLEN.ETS"},
+	"FORECAST.ETS.CONFINT":     {"PROGNOSE.ETS.KONFINT", "PREVISION.ETS.CONFINT", "PRONOSTICO.ETS.CONFINT", "PREVISIONE.ETS.INTCONF", "PREVISÃO.ETS.CONFINT", "ПРЕДСКАЗ.ETS.ДОВИНТЕРВАЛ", "VOORSPELLEN.ETS.CONFINT"},
+	"FORECAST.ETS.SEASONALITY": {"PROGNOSE.ETS.SAISONALITÄT", "PREVISION.ETS.CARACTERESAISONNIER", "PRONOSTICO.ETS.ESTACIONALIDAD", "PREVISIONE.ETS.STAGIONALITÀ", "PREVISÃO.ETS.SAZONALIDADE", "ПРЕДСКАЗ.ETS.СЕЗОННОСТЬ", "VOORSPELLEN.ETS.SEASONALITEIT"},
+	"FORECAST.ETS.STAT":        {"PROGNOSE.ETS.STAT", "PREVISION.ETS.STAT", "PRONOSTICO.ETS.STAT", "PREVISIONE.ETS.STAT", "PREVISÃO.ETS.STAT", "ПРЕДСКАЗ.ETS.СТАТ", "VOORSPELLEN.ETS.STAT"},
+	"FORECAST.LINEAR":          {"PROGNOSE.LINEAR", "PREVISION.LINEAIRE", "PRONOSTICO.LINEAL", "PREVISIONE.LINEARE", "PREVISÃO.LINEAR", "ПРЕДСКАЗ.ЛИНЕЙН", "VOORSPELLEN.LINEAR"},
+	"FORMULATEXT":              {"FORMELTEXT", "FORMULETEXTE", "FORMULATEXTO", "TESTO.FORMULA", "FÓRMULATEXTO", "Ф.ТЕКСТ", "FORMULETEKST"},
+	"FREQUENCY":                {"HÄUFIGKEIT", "FREQUENCE", "FRECUENCIA", "FREQUENZA", "FREQÜÊNCIA", "ЧАСТОТА", "INTERVAL"},
+	"FTEST":                    {"FTEST", "TEST.F", "PRUEBA.F", "TEST.F", "TESTEF", "ФТЕСТ", "F.TOETS"},
+	"FV":                       {"ZW", "VC", "VF", "VAL.FUT", "VF", "БС", "TW"},
+	"FVSCHEDULE":               {"ZW2", "VC.PAIEMENTS", "VF.PLAN", "VAL.FUT.CAPITALE", "VFPLANO", "БЗРАСПИС", "TOEK.WAARDE2"},
+	"GAMMA":                    {"GAMMA", "GAMMA", "GAMMA", "GAMMA", "GAMA", "ГАММА", "GAMMA"},
+	"GAMMA.DIST":               {"GAMMA.VERT", "LOI.GAMMA.N", "DISTR.GAMMA.N", "DISTRIB.GAMMA.N", "DIST.GAMA", "ГАММА.РАСП", "GAMMA.VERD.N"},
+	"GAMMA.INV":                {"GAMMA.INV", "LOI.GAMMA.INVERSE.N", "INV.GAMMA", "INV.GAMMA.N", "INV.GAMA", "ГАММА.ОБР", "GAMMA.INV.N"},
+	"GAMMADIST":                {"GAMMAVERT", "LOI.GAMMA", "DISTR.GAMMA", "DISTRIB.GAMMA", "DISTGAMA", "ГАММАРАСП", "GAMMA.VERD"},
+	"GAMMAINV":                 {"GAMMAINV", "LOI.GAMMA.INVERSE", "DISTR.GAMMA.INV", "INV.GAMMA", "INVGAMA", "ГАММАОБР", "GAMMA.INV"},
+	"GAMMALN":                  {"GAMMALN", "LNGAMMA", "GAMMA.LN", "LN.GAMMA", "LNGAMA", "ГАММАНЛОГ", "GAMMA.LN"},
+	"GAMMALN.PRECISE":          {"GAMMALN.GENAU", "LNGAMMA.PRECIS", "GAMMA.LN.EXACTO", "LN.GAMMA.PRECISA", "LNGAMA.PRECISO", "ГАММАНЛОГ.ТОЧН", "GAMMA.LN.NAUWKEURIG"},
+	"GAUSS":                    {"GAUSS", "GAUSS", "GAUSS", "GAUSS", "GAUSS", "ГАУСС", "GAUSS"},
+	"GCD":                      {"GGT", "PGCD", "M.C.D", "MCD", "MDC", "НОД", "GGD"},
+	"GEOMEAN":                  {"GEOMITTEL", "MOYENNE.GEOMETRIQUE", "MEDIA.GEOM", "MEDIA.GEOMETRICA", "MÉDIA.GEOMÉTRICA", "СРГЕОМ", "MEETK.GEM"},
+	"GESTEP":                   {"GGANZZAHL", "SUP.SEUIL", "MAYOR.O.IGUAL", "SOGLIA", "DEGRAU", "ПОРОГ", "GROTER.DAN"},
+	"GETPIVOTDATA":             {"PIVOTDATENZUORDNEN", "LIREDONNEESTABCROISDYNAMIQUE", "IMPORTARDATOSDINAMICOS", "INFO.DATI.TAB.PIVOT", "INFODADOSTABELADINÂMICA", "ПОЛУЧИТЬ.ДАННЫЕ.СВОДНОЙ.ТАБЛИЦЫ", "DRAAITABEL.OPHALEN"},
+	"GROUPBY":                  {"GROUPBY", "GROUPBY", "GROUPBY", "GROUPBY", "GROUPBY", "GROUPBY", "GROUPBY"},
+	"GROWTH":                   {"VARIATION", "CROISSANCE", "CRECIMIENTO", "CRESCITA", "CRESCIMENTO", "РОСТ", "GROEI"},
+	"HARMEAN":                  {"HARMITTEL", "MOYENNE.HARMONIQUE", "MEDIA.ARMO", "MEDIA.ARMONICA", "MÉDIA.HARMÔNICA", "СРГАРМ", "HARM.GEM"},
+	"HEX2BIN":                  {"HEXINBIN", "HEXBIN", "HEX.A.BIN", "HEX.BINARIO", "HEXABIN", "ШЕСТН.В.ДВ", "HEX.N.BIN"},
+	"HEX2DEC":                  {"HEXINDEZ", "HEXDEC", "HEX.A.DEC", "HEX.DECIMALE", "HEXADEC", "ШЕСТН.В.ДЕС", "HEX.N.DEC"},
+	"HEX2OCT":                  {"HEXINOKT", "HEXOCT", "HEX.A.OCT", "HEX.OCT", "HEXAOCT", "ШЕСТН.В.ВОСЬМ", "HEX.N.OCT"},
+	"HLOOKUP":                  {"WVERWEIS", "RECHERCHEH", "BUSCARH", "CERCA.ORIZZ", "PROCH", "ГПР", "HORIZ.ZOEKEN"},
+	"HOUR":                     {"STUNDE", "HEURE", "HORA", "ORA", "HORA", "ЧАС", "UUR"},
+	"HSTACK":                   {"HSTAPELN", "ASSEMB.H", "APILARH", "STACK.ORIZ", "EMPILHARH", "ГСТОЛБИК", "HSTACK"},
+	"HYPERLINK":                {"HYPERLINK", "LIEN_HYPERTEXTE", "HIPERVINCULO", "COLLEG.IPERTESTUALE", "HIPERLINK", "ГИПЕРССЫЛКА", "HYPERLINK"},
+	"HYPGEOM.DIST":             {"HYPGEOM.VERT", "LOI.HYPERGEOMETRIQUE.N", "DISTR.HIPERGEOM.N", "DISTRIB.IPERGEOM.N", "DIST.HIPERGEOM.N", "ГИПЕРГЕОМ.РАСП", "HYPGEOM.VERD"},
+	"HYPGEOMDIST":              {"HYPGEOMVERT", "LOI.HYPERGEOMETRIQUE", "DISTR.HIPERGEOM", "DISTRIB.IPERGEOM", "DIST.HIPERGEOM", "ГИПЕРГЕОМЕТ", "HYPERGEO.VERD"},
+	"IF":                       {"WENN", "SI", "SI", "SE", "SE", "ЕСЛИ", "ALS"},
+	"IFERROR":                  {"WENNFEHLER", "SIERREUR", "SI.ERROR", "SE.ERRORE", "SEERRO", "ЕСЛИОШИБКА", "ALS.FOUT"},
+	"IFNA":                     {"WENNNV", "SI.NON.DISP", "SI.ND", "SE.NON.DISP.", "SENÃODISP", "ЕСНД", "ALS.NB"},
+	"IFS":                      {"WENNS", "SI.CONDITIONS", "SI.CONJUNTO", "PIÙ.SE", "SES", "ЕСЛИМН", "ALS.VOORWAARDEN"},
+	"IMABS":                    {"IMABS", "COMPLEXE.MODULE", "IM.ABS", "COMP.MODULO", "IMABS", "МНИМ.ABS", "C.ABS"},
+	"IMAGE":                    {"BILD", "IMAGE", "IMAGEN", "IMMAGINE", "IMAGEM", "ИЗОБРАЖЕНИЕ", "AFBEELDING"},
+	"IMAGINARY":                {"IMAGINÄRTEIL", "COMPLEXE.IMAGINAIRE", "IMAGINARIO", "COMP.IMMAGINARIO", "IMAGINÁRIO", "МНИМ.ЧАСТЬ", "C.IM.DEEL"},
+	"IMARGUMENT":               {"IMARGUMENT", "COMPLEXE.ARGUMENT", "IM.ANGULO", "COMP.ARGOMENTO", "IMARG", "МНИМ.АРГУМЕНТ", "C.ARGUMENT"},
+	"IMCONJUGATE":              {"IMKONJUGIERTE", "COMPLEXE.CONJUGUE", "IM.CONJUGADA", "COMP.CONIUGATO", "IMCONJ", "МНИМ.СОПРЯЖ", "C.TOEGEVOEGD"},
+	"IMCOS":                    {"IMCOS", "COMPLEXE.COS", "IM.COS", "COMP.COS", "IMCOS", "МНИМ.COS", "C.COS"},
+	"IMCOSH":                   {"IMCOSHYP", "COMPLEXE.COSH", "IM.COSH", "COMP.COSH", "IMCOSH", "МНИМ.COSH", "C.COSH"},
+	"IMCOT":                    {"IMCOT", "COMPLEXE.COT", "IM.COT", "COMP.COT", "IMCOT", "МНИМ.COT", "C.COT"},
+	"IMCSC":                    {"IMCOSEC", "COMPLEXE.CSC", "IM.CSC", "COMP.CSC", "IMCOSEC", "МНИМ.CSC", "C.COSEC"},
+	"IMCSCH":                   {"IMCOSECHYP", "COMPLEXE.CSCH", "IM.CSCH", "COMP.CSCH", "IMCOSECH", "МНИМ.CSCH", "C.COSECH"},
+	"IMDIV":                    {"IMDIV", "COMPLEXE.DIV", "IM.DIV", "COMP.DIV", "IMDIV", "МНИМ.ДЕЛ", "C.QUOTIENT"},
+	"IMEXP":                    {"IMEXP", "COMPLEXE.EXP", "IM.EXP", "COMP.EXP", "IMEXP", "МНИМ.EXP", "C.EXP"},
+	"IMLN":                     {"IMLN", "COMPLEXE.LN", "IM.LN", "COMP.LN", "IMLN", "МНИМ.LN", "C.LN"},
+	"IMLOG10":                  {"IMLOG10", "COMPLEXE.LOG10", "IM.LOG10", "COMP.LOG10", "IMLOG10", "МНИМ.LOG10", "C.LOG10"},
+	"IMLOG2":                   {"IMLOG2", "COMPLEXE.LOG2", "IM.LOG2", "COMP.LOG2", "IMLOG2", "МНИМ.LOG2", "C.LOG2"},
+	"IMPOWER":                  {"IMAPOTENZ", "COMPLEXE.PUISSANCE", "IM.POT", "COMP.POTENZA", "IMPOT", "МНИМ.СТЕПЕНЬ", "C.MACHT"},
+	"IMPRODUCT":                {"IMPRODUKT", "COMPLEXE.PRODUIT", "IM.PRODUCT", "COMP.PRODOTTO", "IMPRODUTO", "МНИМ.ПРОИЗВЕД", "C.PRODUCT"},
+	"IMREAL":                   {"IMREALTEIL", "COMPLEXE.REEL", "IM.REAL", "COMP.PARTE.REALE", "IMREAL", "МНИМ.ВЕЩ", "C.REEEL.DEEL"},
+	"IMSEC":                    {"IMSEC", "COMPLEXE.SEC", "IM.SEC", "COMP.SEC", "IMSEC", "МНИМ.SEC", "C.SEC"},
+	"IMSECH":                   {"IMSECHYP", "COMPLEXE.SECH", "IM.SECH", "COMP.SECH", "IMSECH", "МНИМ.SECH", "C.SECH"},
+	"IMSIN":                    {"IMSIN", "COMPLEXE.SIN", "IM.SENO", "COMP.SEN", "IMSENO", "МНИМ.SIN", "C.SIN"},
+	"IMSINH":                   {"IMSINHYP", "COMPLEXE.SINH", "IM.SENOH", "COMP.SENH", "IMSENH", "МНИМ.SINH", "C.SINH"},
+	"IMSQRT":                   {"IMWURZEL", "COMPLEXE.RACINE", "IM.RAIZ2", "COMP.RADQ", "IMRAIZ", "МНИМ.КОРЕНЬ", "C.WORTEL"},
+	"IMSUB":                    {"IMSUB", "COMPLEXE.DIFFERENCE", "IM.SUSTR", "COMP.DIFF", "IMSUBTR", "МНИМ.РАЗН", "C.VERSCHIL"},
+	"IMSUM":                    {"IMSUMME", "COMPLEXE.SOMME", "IM.SUM", "COMP.SOMMA", "IMSOMA", "МНИМ.СУММ", "C.SOM"},
+	"IMTAN":                    {"IMTAN", "COMPLEXE.TAN", "IM.TAN", "COMP.TAN", "IMTAN", "МНИМ.TAN", "C.TAN"},
+	"INDEX":                    {"INDEX", "INDEX", "INDICE", "INDICE", "ÍNDICE", "ИНДЕКС", "INDEX"},
+	"INDIRECT":                 {"INDIREKT", "INDIRECT", "INDIRECTO", "INDIRETTO", "INDIRETO", "ДВССЫЛ", "INDIRECT"},
+	"INFO":                     {"INFO", "INFORMATIONS", "INFO", "AMBIENTE.INFO", "INFORMAÇÃO", "ИНФОР", "INFO"},
+	"INT":                      {"GANZZAHL", "ENT", "ENTERO", "INT", "INT", "ЦЕЛОЕ", "INTEGER"},
+	"INTERCEPT":                {"ACHSENABSCHNITT", "ORDONNEE.ORIGINE", "INTERSECCION.EJE", "INTERCETTA", "INTERCEPÇÃO", "ОТРЕЗОК", "SNIJPUNT"},
+	"INTRATE":                  {"ZINSSATZ", "TAUX.INTERET", "TASA.INT", "TASSO.INT", "TAXAJUROS", "ИНОРМА", "RENTEPERCENTAGE"},
+	"IPMT":                     {"ZINSZ", "INTPER", "PAGOINT", "INTERESSI", "IPGTO", "ПРПЛТ", "IBET"},
+	"IRR":                      {"IKV", "TRI", "TIR", "TIR.COST", "TIR", "ВСД", "IR"},
+	"ISBLANK":                  {"ISTLEER", "ESTVIDE", "ESBLANCO", "VAL.VUOTO", "ÉCÉL.VAZIA", "ЕПУСТО", "ISLEEG"},
+	"ISERR":                    {"ISTFEHL", "ESTERR", "ESERR", "VAL.ERR", "ÉERRO", "ЕОШ", "ISFOUT2"},
+	"ISERROR":                  {"ISTFEHLER", "ESTERREUR", "ESERROR", "VAL.ERRORE", "ÉERROS", "ЕОШИБКА", "ISFOUT"},
+	"ISEVEN":                   {"ISTGERADE", "EST.PAIR", "ES.PAR", "VAL.PARI", "ÉPAR", "ЕЧЁТН", "IS.EVEN"},
+	"ISFORMULA":                {"ISTFORMEL", "ESTFORMULE", "ESFORMULA", "VAL.FORMULA", "ÉFÓRMULA", "ЕФОРМУЛА", "ISFORMULE"},
+	"ISLOGICAL":                {"ISTLOG", "ESTLOGIQUE", "ESLOGICO", "VAL.LOGICO", "ÉLÓGICO", "ЕЛОГИЧ", "ISLOGISCH"},
+	"ISNA":                     {"ISTNV", "ESTNA", "ESNOD", "VAL.NON.DISP", "É.NÃO.DISP", "ЕНД", "ISNB"},
+	"ISNONTEXT":                {"ISTKTEXT", "ESTNONTEXTE", "ESNOTEXTO", "VAL.NON.TESTO", "ÉNÃOTEXTO", "ЕНЕТЕКСТ", "ISGEENTEKST"},
+	"ISNUMBER":                 {"ISTZAHL", "ESTNUM", "ESNUMERO", "VAL.NUMERO", "ÉNÚM", "ЕЧИСЛО", "ISGETAL"},
+	"ISO.CEILING":              {"ISO.OBERGRENZE", "ISO.PLAFOND", "MULTIPLO.SUPERIOR.ISO", "ISO.ARROTONDA.ECCESSO", "ISO.TETO", "ISO.ОКРВВЕРХ", "ISO.AFRONDEN.BOVEN"},
+	"ISODD":                    {"ISTUNGERADE", "EST.IMPAIR", "ES.IMPAR", "VAL.DISPARI", "ÉIMPAR", "ЕНЕЧЁТ", "IS.ONEVEN"},
+	"ISOMITTED":                {"ISTAUSGELASSEN", "ISOMITTED", "ISOMITTED", "ISOMITTED", "ISOMITTED", "ISOMITTED", "ISOMITTED"},
+	"ISOWEEKNUM":               {"ISOKALENDERWOCHE", "NO.SEMAINE.ISO", "ISO.NUM.DE.SEMANA", "NUM.SETTIMANA.ISO", "NÚMSEMANAISO", "НОМНЕДЕЛИ.ISO", "ISO.WEEKNUMMER"},
+	"ISPMT":                    {"ISPMT", "ISPMT", "INT.PAGO.DIR", "INTERESSE.RATA", "ÉPGTO", "ПРОЦПЛАТ", "ISBET"},
+	"ISREF":                    {"ISTBEZUG", "ESTREF", "ESREF", "VAL.RIF", "ÉREF", "ЕССЫЛКА", "ISVERWIJZING"},
+	"ISTEXT":                   {"ISTTEXT", "ESTTEXTE", "ESTEXTO", "VAL.TESTO", "ÉTEXTO", "ЕТЕКСТ", "ISTEKST"},
+	"KURT":                     {"KURT", "KURTOSIS", "CURTOSIS", "CURTOSI", "CURT", "ЭКСЦЕСС", "KURTOSIS"},
+	"LAMBDA":                   {"LAMBDA", "LAMBDA", "LAMBDA", "LAMBDA", "LAMBDA", "LAMBDA", "LAMBDA"},
+	"LARGE":                    {"KGRÖSSTE", "GRANDE.VALEUR", "K.ESIMO.MAYOR", "GRANDE", "MAIOR", "НАИБОЛЬШИЙ", "GROOTSTE"},
+	"LCM":                      {"KGV", "PPCM", "M.C.M", "MCM", "MMC", "НОК", "KGV"},
+	"LEFT":                     {"LINKS", "GAUCHE", "IZQUIERDA", "SINISTRA", "ESQUERDA", "ЛЕВСИМВ", "LINKS"},
+	"LEFTB":                    {"LINKSB", "GAUCHEB", "IZQUIERDAB", "SINISTRA.B", "ESQUERDAB", "ЛЕВБ", "LINKSB"},
+	"LEN":                      {"LÄNGE", "NBCAR", "LARGO", "LUNGHEZZA", "NÚM.CARACT", "ДЛСТР", "LENGTE"},
+	"LENB":                     {"LÄNGEB", "LENB", "LARGOB", "LUNGB", "NÚMBBYTES", "ДЛСТРБ", "LENGTEB"},
+	"LET":                      {"LET", "LET", "LET", "LET", "LET", "LET", "LET"},
+	"LINEST":                   {"RGP", "DROITEREG", "ESTIMACION.LINEAL", "REGR.LIN", "PROJ.LIN", "ЛИНЕЙН", "LIJNSCH"},
+	"LN":                       {"LN", "LN", "LN", "LN", "LN", "LN", "LN"},
+	"LOG":                      {"LOG", "LOG", "LOG", "LOG", "LOG", "LOG", "LOG"},
+	"LOG10":                    {"LOG10", "LOG10", "LOG10", "LOG10", "LOG10", "LOG10", "LOG10"},
+	"LOGEST":                   {"RKP", "LOGREG", "ESTIMACION.LOGARITMICA", "REGR.LOG", "PROJ.LOG", "ЛГРФПРИБЛ", "LOGSCH"},
+	"LOGINV":                   {"LOGINV", "LOI.LOGNORMALE.INVERSE", "DISTR.LOG.INV", "INV.LOGNORM", "INVLOG", "ЛОГНОРМОБР", "LOG.NORM.INV"},
+	"LOGNORM.DIST":             {"LOGNORM.VERT", "LOI.LOGNORMALE.N", "DISTR.LOGNORM", "DISTRIB.LOGNORM.N", "DIST.LOGNORMAL.N", "ЛОГНОРМ.РАСП", "LOGNORM.VERD"},
+	"LOGNORM.INV":              {"LOGNORM.INV", "LOI.LOGNORMALE.INVERSE.N", "INV.LOGNORM", "INV.LOGNORM.N", "INV.LOGNORMAL", "ЛОГНОРМ.ОБР", "LOGNORM.INV"},
+	"LOGNORMDIST":              {"LOGNORMVERT", "LOI.LOGNORMALE", "DISTR.LOG.NORM", "DISTRIB.LOGNORM", "DISTLOGNORMAL", "ЛОГНОРМРАСП", "LOG.NORM.VERD"},
+	"LOOKUP":                   {"VERWEIS", "RECHERCHE", "BUSCAR", "CERCA", "PROC", "ПРОСМОТР", "ZOEKEN"},
+	"LOWER":                    {"KLEIN", "MINUSCULE", "MINUSC", "MINUSC", "MINÚSCULA", "СТРОЧН", "KLEINE.LETTERS"},
+	"MAKEARRAY":                {"MATRIXERSTELLEN", "MAKEARRAY", "MAKEARRAY", "MAKEARRAY", "MAKEARRAY", "MAKEARRAY", "MAKEARRAY"},
+	"MAP":                      {"ZUORDNEN", "MAP", "MAP", "MAP", "MAP", "MAP", "MAP"},
+	"MATCH":                    {"VERGLEICH", "EQUIV", "COINCIDIR", "CONFRONTA", "CORRESP", "ПОИСКПОЗ", "VERGELIJKEN"},
+	"MAX":                      {"MAX", "MAX", "MAX", "MAX", "MÁXIMO", "МАКС", "MAX"},
+	"MAXA":                     {"MAXA", "MAXA", "MAXA", "MAX.VALORI", "MÁXIMOA", "МАКСА", "MAXA"},
+	"MAXIFS":                   {"MAXWENNS", "MAX.SI.ENS", "MAX.SI.CONJUNTO", "MAX.PIÙ.SE", "MÁXIMOSES", "МАКСЕСЛИ", "MAX.ALS.VOORWAARDEN"},
+	"MDETERM":                  {"MDET", "DETERMAT", "MDETERM", "MATR.DETERM", "MATRIZ.DETERM", "МОПРЕД", "DETERMINANTMAT"},
+	"MDURATION":                {"MDURATION", "DUREE.MODIFIEE", "DURACION.MODIF", "DURATA.M", "MDURAÇÃO", "МДЛИТ", "AANG.DUUR"},
+	"MEDIAN":                   {"MEDIAN", "MEDIANE", "MEDIANA", "MEDIANA", "MED", "МЕДИАНА", "MEDIAAN"},
+	"MID":                      {"TEIL", "STXT", "EXTRAE", "STRINGA.ESTRAI", "EXT.TEXTO", "ПСТР", "DEEL"},
+	"MIDB":                     {"TEILB", "STXTB", "EXTRAEB", "MEDIA.B", "EXTRAIRB", "ПСТРБ", "DEELB"},
+	"MIN":                      {"MIN", "MIN", "MIN", "MIN", "MÍNIMO", "МИН", "MIN"},
+	"MINA":                     {"MINA", "MINA", "MINA", "MIN.VALORI", "MÍNIMOA", "МИНА", "MINA"},
+	"MINIFS":                   {"MINWENNS", "MIN.SI.ENS", "MIN.SI.CONJUNTO", "MIN.PIÙ.SE", "MÍNIMOSES", "МИНЕСЛИ", "MIN.ALS.VOORWAARDEN"},
+	"MINUTE":                   {"MINUTE", "MINUTE", "MINUTO", "MINUTO", "MINUTO", "МИНУТЫ", "MINUUT"},
+	"MINVERSE":                 {"MINV", "INVERSEMAT", "MINVERSA", "MATR.INVERSA", "MATRIZ.INVERSO", "МОБР", "INVERSEMAT"},
+	"MIRR":                     {"QIKV", "TRIM", "TIRM", "TIR.VAR", "MTIR", "МВСД", "GIR"},
+	"MMULT":                    {"MMULT", "PRODUITMAT", "MMULT", "MATR.PRODOTTO", "MATRIZ.MULT", "МУМНОЖ", "PRODUCTMAT"},
+	"MOD":                      {"REST", "MOD", "RESIDUO", "RESTO", "MOD", "ОСТАТ", "REST"},
+	"MODE":                     {"MODALWERT", "MODE", "MODA", "MODA", "MODO", "МОДА", "MODUS"},
+	"MODE.MULT":                {"MODUS.VIELF", "MODE.MULTIPLE", "MODA.VARIOS", "MODA.MULT", "MODO.MULT", "МОДА.НСК", "MODUS.MEERDERE"},
+	"MODE.SNGL":                {"MODUS.EINF", "MODE.SIMPLE", "MODA.UNO", "MODA.SNGL", "MODO.ÚNICO", "МОДА.ОДН", "MODUS.ENKELV"},
+	"MONTH":                    {"MONAT", "MOIS", "MES", "MESE", "MÊS", "МЕСЯЦ", "MAAND"},
+	"MROUND":                   {"VRUNDEN", "ARRONDI.AU.MULTIPLE", "REDOND.MULT", "ARROTONDA.MULTIPLO", "MARRED", "ОКРУГЛТ", "AFRONDEN.N.VEELVOUD"},
+	"MULTINOMIAL":              {"POLYNOMIAL", "MULTINOMIALE", "MULTINOMIAL", "MULTINOMIALE", "MULTINOMIAL", "МУЛЬТИНОМ", "MULTINOMIAAL"},
+	"MUNIT":                    {"MEINHEIT", "MATRICE.UNITAIRE", "M.UNIDAD", "MATR.UNIT", "MUNIT", "МЕДИН", "EENHEIDMAT"},
+	"N":                        {"N", "N", "N", "NUM", "N", "Ч", "N"},
+	"NA":                       {"NV", "NA", "NOD", "NON.DISP", "NÃO.DISP", "НД", "NB"},
+	"NEGBINOM.DIST":            {"NEGBINOM.VERT", "LOI.BINOMIALE.NEG.N", "NEGBINOM.DIST", "DISTRIB.BINOM.NEG.N", "DIST.BIN.NEG.N", "ОТРБИНОМ.РАСП", "NEGBINOM.VERD"},
+	"NEGBINOMDIST":             {"NEGBINOMVERT", "LOI.BINOMIALE.NEG", "NEGBINOMDIST", "DISTRIB.BINOM.NEG", "DIST.BIN.NEG", "ОТРБИНОМРАСП", "NEG.BINOM.VERD"},
+	"NETWORKDAYS":              {"NETTOARBEITSTAGE", "NB.JOURS.OUVRES", "DIAS.LAB", "GIORNI.LAVORATIVI.TOT", "DIATRABALHOTOTAL", "ЧИСТРАБДНИ", "NETTO.WERKDAGEN"},
+	"NETWORKDAYS.INTL":         {"NETTOARBEITSTAGE.INTL", "NB.JOURS.OUVRES.INTL", "DIAS.LAB.INTL", "GIORNI.LAVORATIVI.TOT.INTL", "DIATRABALHOTOTAL.INTL", "ЧИСТРАБДНИ.МЕЖД", "NETWERKDAGEN.INTL"},
+	"NOMINAL":                  {"NOMINAL", "TAUX.NOMINAL", "TASA.NOMINAL", "NOMINALE", "NOMINAL", "НОМИНАЛ", "NOMINALE.RENTE"},
+	"NORM.DIST":                {"NORM.VERT", "LOI.NORMALE.N", "DISTR.NORM.N", "DISTRIB.NORM.N", "DIST.NORM.N", "НОРМ.РАСП", "NORM.VERD.N"},
+	"NORM.INV":                 {"NORM.INV", "LOI.NORMALE.INVERSE.N", "INV.NORM", "INV.NORM.N", "INV.NORM.N", "НОРМ.ОБР", "NORM.INV.N"},
+	"NORM.S.DIST":              {"NORM.S.VERT", "LOI.NORMALE.STANDARD.N", "DISTR.NORM.ESTAND.N", "DISTRIB.NORM.ST.N", "DIST.NORMP.N", "НОРМ.СТ.РАСП", "NORM.S.VERD"},
+	"NORM.S.INV":               {"NORM.S.INV", "LOI.NORMALE.STANDARD.INVERSE.N", "INV.NORM.ESTAND", "INV.NORM.S", "INV.NORMP.N", "НОРМ.СТ.ОБР", "NORM.S.INV"},
+	"NORMDIST":                 {"NORMVERT", "LOI.NORMALE", "DISTR.NORM", "DISTRIB.NORM", "DISTNORM", "НОРМРАСП", "NORM.VERD"},
+	"NORMINV":                  {"NORMINV", "LOI.NORMALE.INVERSE", "DISTR.NORM.INV", "INV.NORM", "INV.NORM", "НОРМОБР", "NORM.INV"},
+	"NORMSDIST":                {"STANDNORMVERT", "LOI.NORMALE.STANDARD", "DISTR.NORM.ESTAND", "DISTRIB.NORM.ST", "DISTNORMP", "НОРМСТРАСП", "STAND.NORM.VERD"},
+	"NORMSINV":                 {"STANDNORMINV", "LOI.NORMALE.STANDARD.INVERSE", "DISTR.NORM.ESTAND.INV", "INV.NORM.ST", "INVNORMP", "НОРМСТОБР", "STAND.NORM.INV"},
+	"NOT":                      {"NICHT", "NON", "NO", "NON", "NÃO", "НЕ", "NIET"},
+	"NOW":                      {"JETZT", "MAINTENANT", "AHORA", "ADESSO", "AGORA", "ТДАТА", "NU"},
+	"NPER":                     {"ZZR", "NPM", "NPER", "NUM.RATE", "NPER", "КПЕР", "NPER"},
+	"NPV":                      {"NBW", "VAN", "VNA", "VAN", "VPL", "ЧПС", "NHW"},
+	"NUMBERVALUE":              {"ZAHLENWERT", "VALEURNOMBRE", "VALOR.NUMERO", "NUMERO.VALORE", "VALORNUMÉRICO", "ЧЗНАЧ", "NUMERIEKE.WAARDE"},
+	"OCT2BIN":                  {"OKTINBIN", "OCTBIN", "OCT.A.BIN", "OCT.BINARIO", "OCTABIN", "ВОСЬМ.В.ДВ", "OCT.N.BIN"},
+	"OCT2DEC":                  {"OKTINDEZ", "OCTDEC", "OCT.A.DEC", "OCT.DECIMALE", "OCTADEC", "ВОСЬМ.В.ДЕС", "OCT.N.DEC"},
+	"OCT2HEX":                  {"OKTINHEX", "OCTHEX", "OCT.A.HEX", "OCT.HEX", "OCTAHEX", "ВОСЬМ.В.ШЕСТН", "OCT.N.HEX"},
+	"ODD":                      {"UNGERADE", "IMPAIR", "REDONDEA.IMPAR", "DISPARI", "ÍMPAR", "НЕЧЁТ", "ONEVEN"},
+	"ODDFPRICE":                {"UNREGER.KURS", "PRIX.PCOUPON.IRREG", "PRECIO.PER.IRREGULAR.1", "PREZZO.PRIMO.IRR", "PREÇOPRIMINC", "ЦЕНАПЕРВНЕРЕГ", "AFW.ET.PRIJS"},
+	"ODDFYIELD":                {"UNREGER.REND", "REND.PCOUPON.IRREG", "RENDTO.PER.IRREGULAR.1", "REND.PRIMO.IRR", "LUCROPRIMINC", "ДОХОДПЕРВНЕРЕГ", "AFW.ET.REND"},
+	"ODDLPRICE":                {"UNREGLE.KURS", "PRIX.DCOUPON.IRREG", "PRECIO.PER.IRREGULAR.2", "PREZZO.ULTIMO.IRR", "PREÇOÚLTINC", "ЦЕНАПОСЛНЕРЕГ", "AFW.LT.PRIJS"},
+	"ODDLYIELD":                {"UNREGLE.REND", "REND.DCOUPON.IRREG", "RENDTO.PER.IRREGULAR.2", "REND.ULTIMO.IRR", "LUCROÚLTINC", "ДОХОДПОСЛНЕРЕГ", "AFW.LT.REND"},
+	"OFFSET":                   {"BEREICH.VERSCHIEBEN", "DECALER", "DESREF", "SCARTO", "DESLOC", "СМЕЩ", "VERSCHUIVING"},
+	"OR":                       {"ODER", "OU", "O", "O", "OU", "ИЛИ", "OF"},
+	"PDURATION":                {"PDURATION", "PDUREE", "P.DURACION", "DURATA.P", "PDURAÇÃO", "ПДЛИТ", "PDUUR"},
+	"PEARSON":                  {"PEARSON", "PEARSON", "PEARSON", "PEARSON", "PEARSON", "PEARSON", "PEARSON"},
+	"PERCENTILE":               {"QUANTIL", "CENTILE", "PERCENTIL", "PERCENTILE", "PERCENTIL", "ПЕРСЕНТИЛЬ", "PERCENTIEL"},
+	"PERCENTILE.EXC":           {"QUANTIL.EXKL", "CENTILE.EXCLURE", "PERCENTIL.EXC", "ESC.PERCENTILE", "PERCENTIL.EXC", "ПРОЦЕНТИЛЬ.ИСКЛ", "PERCENTIEL.EXC"},
+	"PERCENTILE.INC":           {"QUANTIL.INKL", "CENTILE.INCLURE", "PERCENTIL.INC", "INC.PERCENTILE", "PERCENTIL.INC", "ПРОЦЕНТИЛЬ.ВКЛ", "PERCENTIEL.INC"},
+	"PERCENTOF":                {"PERCENTOF", "PERCENTOF", "PERCENTOF", "PERCENTOF", "PERCENTOF", "PERCENTOF", "PERCENTOF"},
+	"PERCENTRANK":              {"QUANTILSRANG", "RANG.POURCENTAGE", "RANGO.PERCENTIL", "PERCENT.RANGO", "ORDEM.PORCENTUAL", "ПРОЦЕНТРАНГ", "PERCENT.RANG"},
+	"PERCENTRANK.EXC":          {"QUANTILSRANG.EXKL", "RANG.POURCENTAGE.EXCLURE", "RANGO.PERCENTIL.EXC", "ESC.PERCENT.RANGO", "ORDEM.PORCENTUAL.EXC", "ПРОЦЕНТРАНГ.ИСКЛ", "PROCENTRANG.EXC"},
+	"PERCENTRANK.INC":          {"QUANTILSRANG.INKL", "RANG.POURCENTAGE.INCLURE", "RANGO.PERCENTIL.INC", "INC.PERCENT.RANGO", "ORDEM.PORCENTUAL.INC", "ПРОЦЕНТРАНГ.ВКЛ", "PROCENTRANG.INC"},
+	"PERMUT":                   {"VARIATIONEN", "PERMUTATION", "PERMUTACIONES", "PERMUTAZIONE", "PERMUT", "ПЕРЕСТ", "PERMUTATIES"},
+	"PERMUTATIONA":             {"VARIATIONEN2", "PERMUTATIONA", "PERMUTACIONES.A", "PERMUTAZIONE.VALORI", "PERMUTAS", "ПЕРЕСТА", "PERMUTATIE.A"},
+	"PHI":                      {"PHI", "PHI", "FI", "PHI", "PHI", "ФИ", "PHI"},
+	"PHONETIC":                 {"PHONETIC", "PHONETIQUE", "FONETICO", "FURIGANA", "FONÉTICA", "PHONETIC", "FONETISCH"},
+	"PI":                       {"PI", "PI", "PI", "PI.GRECO", "PI", "ПИ", "PI"},
+	"PIVOTBY":                  {"PIVOTBY", "PIVOTBY", "PIVOTBY", "PIVOTBY", "PIVOTBY", "PIVOTBY", "PIVOTBY"},
+	"PMT":                      {"RMZ", "VPM", "PAGO", "RATA", "PGTO", "ПЛТ", "BET"},
+	"POISSON":                  {"POISSON", "LOI.POISSON", "POISSON", "POISSON", "POISSON", "ПУАССОН", "POISSON"},
+	"POISSON.DIST":             {"POISSON.VERT", "LOI.POISSON.N", "POISSON.DIST", "DISTRIB.POISSON", "DIST.POISSON", "ПУАССОН.РАСП", "POISSON.VERD"},
+	"POWER":                    {"POTENZ", "PUISSANCE", "POTENCIA", "POTENZA", "POTÊNCIA", "СТЕПЕНЬ", "MACHT"},
+	"PPMT":                     {"KAPZ", "PRINCPER", "PAGOPRIN", "P.RATA", "PPGTO", "ОСПЛТ", "PBET"},
+	"PRICE":                    {"KURS", "PRIX.TITRE", "PRECIO", "PREZZO", "PREÇO", "ЦЕНА", "PRIJS.NOM"},
+	"PRICEDISC":                {"KURSDISAGIO", "VALEUR.ENCAISSEMENT", "PRECIO.DESCUENTO", "PREZZO.SCONT", "PREÇODESC", "ЦЕНАСКИДКА", "PRIJS.DISCONTO"},
+	"PRICEMAT":                 {"KURSFÄLLIG", "PRIX.TITRE.ECHEANCE", "PRECIO.VENCIMIENTO", "PREZZO.SCAD", "PREÇOVENC", "ЦЕНАПОГАШ", "PRIJS.VERVALDAG"},
+	"PROB":                     {"WAHRSCHBEREICH", "PROBABILITE", "PROBABILIDAD", "PROBABILITÀ", "PROB", "ВЕРОЯТНОСТЬ", "KANS"},
+	"PRODUCT":                  {"PRODUKT", "PRODUIT", "PRODUCTO", "PRODOTTO", "MULT", "ПРОИЗВЕД", "PRODUCT"},
+	"PROPER":                   {"GROSS2", "NOMPROPRE", "NOMPROPIO", "MAIUSC.INIZ", "PRI.MAIÚSCULA", "ПРОПНАЧ", "BEGINLETTERS"},
+	"PV":                       {"BW", "VA", "VA", "VA", "VP", "ПС", "HW"},
+	"QUARTILE":                 {"QUARTILE", "QUARTILE", "CUARTIL", "QUARTILE", "QUARTIL", "КВАРТИЛЬ", "KWARTIEL"},
+	"QUARTILE.EXC":             {"QUARTILE.EXKL", "QUARTILE.EXCLURE", "CUARTIL.EXC", "ESC.QUARTILE", "QUARTIL.EXC", "КВАРТИЛЬ.ИСКЛ", "KWARTIEL.EXC"},
+	"QUARTILE.INC":             {"QUARTILE.INKL", "QUARTILE.INCLURE", "CUARTIL.INC", "INC.QUARTILE", "QUARTIL.INC", "КВАРТИЛЬ.ВКЛ", "KWARTIEL.INC"},
+	"QUOTIENT":                 {"QUOTIENT", "QUOTIENT", "COCIENTE", "QUOZIENTE", "QUOCIENTE", "ЧАСТНОЕ", "QUOTIENT"},
+	"RADIANS":                  {"BOGENMASS", "RADIANS", "RADIANES", "RADIANTI", "RADIANOS", "РАДИАНЫ", "RADIALEN"},
+	"RAND":                     {"ZUFALLSZAHL", "ALEA", "ALEATORIO", "CASUALE", "ALEATÓRIO", "СЛЧИС", "ASELECT"},
+	"RANDARRAY":                {"ZUFALLSMATRIX", "TABLEAU.ALEA", "MATRIZALEAT", "MATR.CASUALE", "MATRIZALEATÓRIA", "СЛМАССИВ", "ASELECT.MATRIX"},
+	"RANDBETWEEN":              {"ZUFALLSBEREICH", "ALEA.ENTRE.BORNES", "ALEATORIO.ENTRE", "CASUALE.TRA", "ALEATÓRIOENTRE", "СЛУЧМЕЖДУ", "ASELECTTUSSEN"},
+	"RANK":                     {"RANG", "RANG", "JERARQUIA", "RANGO", "ORDEM", "РАНГ", "RANG"},
+	"RANK.AVG":                 {"RANG.MITTELW", "MOYENNE.RANG", "JERARQUIA.MEDIA", "RANGO.MEDIA", "ORDEM.MÉD", "РАНГ.СР", "RANG.GEMIDDELDE"},
+	"RANK.EQ":                  {"RANG.GLEICH", "EQUATION.RANG", "JERARQUIA.EQV", "RANGO.UG", "ORDEM.EQ", "РАНГ.РВ", "RANG.GELIJK"},
+	"RATE":                     {"ZINS", "TAUX", "TASA", "TASSO", "TAXA", "СТАВКА", "RENTE"},
+	"RECEIVED":                 {"AUSZAHLUNG", "VALEUR.NOMINALE", "CANTIDAD.RECIBIDA", "RICEV.SCAD", "RECEBER", "ПОЛУЧЕНО", "OPBRENGST"},
+	"REDUCE":                   {"REDUCE", "REDUCE", "REDUCE", "REDUCE", "REDUCE", "REDUCE", "REDUCE"},
+	"REGEXEXTRACT":             {"REGEXEXTRACT", "REGEXEXTRACT", "REGEXEXTRACT", "REGEXEXTRACT", "REGEXEXTRACT", "REGEXEXTRACT", "REGEXEXTRACT"},
+	"REGEXREPLACE":             {"REGEXREPLACE", "REGEXREPLACE", "REGEXREPLACE", "REGEXREPLACE", "REGEXREPLACE", "REGEXREPLACE", "REGEXREPLACE"},
+	"REGEXTEST":                {"REGEXTEST", "REGEXTEST", "REGEXTEST", "REGEXTEST", "REGEXTEST", "REGEXTEST", "REGEXTEST"},
+	"REPLACE":                  {"ERSETZEN", "REMPLACER", "REEMPLAZAR", "RIMPIAZZA", "MUDAR", "ЗАМЕНИТЬ", "VERVANGEN"},
+	"REPLACEB":                 {"ERSETZENB", "REMPLACERB", "REEMPLAZARB", "SOSTITUISCI.B", "MUDARB", "ЗАМЕНИТЬБ", "VERVANGENB"},
+	"REPT":                     {"WIEDERHOLEN", "REPT", "REPETIR", "RIPETI", "REPT", "ПОВТОР", "HERHALING"},
+	"RIGHT":                    {"RECHTS", "DROITE", "DERECHA", "DESTRA", "DIREITA", "ПРАВСИМВ", "RECHTS"},
+	"RIGHTB":                   {"RECHTSB", "DROITEB", "DERECHAB", "DESTRA.B", "DIREITAB", "ПРАВБ", "RECHTSB"},
+	"ROMAN":                    {"RÖMISCH", "ROMAIN", "NUMERO.ROMANO", "ROMANO", "ROMANO", "РИМСКОЕ", "ROMEINS"},
+	"ROUND":                    {"RUNDEN", "ARRONDI", "REDONDEAR", "ARROTONDA", "ARRED", "ОКРУГЛ", "AFRONDEN"},
+	"ROUNDDOWN":                {"ABRUNDEN", "ARRONDI.INF", "REDONDEAR.MENOS", "ARROTONDA.PER.DIF", "ARREDONDAR.PARA.BAIXO", "ОКРУГЛВНИЗ", "AFRONDEN.NAAR.BENEDEN"},
+	"ROUNDUP":                  {"AUFRUNDEN", "ARRONDI.SUP", "REDONDEAR.MAS", "ARROTONDA.PER.ECC", "ARREDONDAR.PARA.CIMA", "ОКРУГЛВВЕРХ", "AFRONDEN.NAAR.BOVEN"},
+	"ROW":                      {"ZEILE", "LIGNE", "FILA", "RIF.RIGA", "LIN", "СТРОКА", "RIJ"},
+	"ROWS":                     {"ZEILEN", "LIGNES", "FILAS", "RIGHE", "LINS", "ЧСТРОК", "RIJEN"},
+	"RRI":                      {"ZSATZINVEST", "TAUX.INT.EQUIV", "RRI", "RIT.INVEST.EFFETT", "EQUIVALENCIA", "ЭКВ.СТАВКА", "RRI"},
+	"RSQ":                      {"BESTIMMTHEITSMASS", "COEFFICIENT.DETERMINATION", "COEFICIENTE.R2", "RQ", "RQUAD", "КВПИРСОН", "R.KWADRAAT"},
+	"RTD":                      {"RTD", "RTD", "RDTR", "RTD", "RTD", "ДРВ", "RTD"},
+	"SCAN":                     {"SCAN", "SCAN", "SCAN", "SCAN", "SCAN", "SCAN", "SCAN"},
+	"SEARCH":                   {"SUCHEN", "CHERCHE", "HALLAR", "RICERCA", "LOCALIZAR", "ПОИСК", "VIND.SPEC"},
+	"SEARCHB":                  {"SUCHENB", "CHERCHERB", "HALLARB", "CERCA.B", "LOCALIZARB", "ПОИСКБ", "VIND.SPEC.B"},
+	"SEC":                      {"SEC", "SEC", "SEC", "SEC", "SEC", "SEC", "SEC"},
+	"SECH":                     {"SECHYP", "SECH", "SECH", "SECH", "SECH", "SECH", "SECH"},
+	"SECOND":                   {"SEKUNDE", "SECONDE", "SEGUNDO", "SECONDO", "SEGUNDO", "СЕКУНДЫ", "SECONDE"},
+	"SEQUENCE":                 {"SEQUENZ", "SEQUENCE", "SECUENCIA", "SEQUENZA", "SEQUÊNCIA", "ПОСЛЕДОВ", "REEKS"},
+	"SERIESSUM":                {"POTENZREIHE", "SOMME.SERIES", "SUMA.SERIES", "SOMMA.SERIE", "SOMASEQÜÊNCIA", "РЯД.СУММ", "SOM.MACHTREEKS"},
+	"SHEET":                    {"BLATT", "FEUILLE", "HOJA", "FOGLIO", "PLAN", "ЛИСТ", "BLAD"},
+	"SHEETS":                   {"BLÄTTER", "FEUILLES", "HOJAS", "FOGLI", "PLANS", "ЛИСТЫ", "BLADEN"},
+	"SIGN":                     {"VORZEICHEN", "SIGNE", "SIGNO", "SEGNO", "SINAL", "ЗНАК", "POS.NEG"},
+	"SIN":                      {"SIN", "SIN", "SENO", "SEN", "SEN", "SIN", "SIN"},
+	"SINGLE":                   {"SINGLE", "SINGLE", "SINGLE", "SINGLE", "SINGLE", "SINGLE", "SINGLE"},
+	"SINH":                     {"SINHYP", "SINH", "SENOH", "SENH", "SENH", "SINH", "SINH"},
+	"SKEW":                     {"SCHIEFE", "COEFFICIENT.ASYMETRIE", "COEFICIENTE.ASIMETRIA", "ASIMMETRIA", "DISTORÇÃO", "СКОС", "SCHEEFHEID"},
+	"SKEW.P":                   {"SCHIEFE.P", "COEFFICIENT.ASYMETRIE.P", "COEFICIENTE.ASIMETRIA.P", "ASIMMETRIA.P", "DISTORÇÃO.P", "СКОС.Г", "SCHEEFHEID.P"},
+	"SLN":                      {"LIA", "AMORLIN", "SLN", "AMMORT.COST", "DPD", "АПЛ", "LIN.AFSCHR"},
+	"SLOPE":                    {"STEIGUNG", "PENTE", "PENDIENTE", "PENDENZA", "INCLINAÇÃO", "НАКЛОН", "RICHTING"},
+	"SMALL":                    {"KKLEINSTE", "PETITE.VALEUR", "K.ESIMO.MENOR", "PICCOLO", "MENOR", "НАИМЕНЬШИЙ", "KLEINSTE"},
+	"SORT":                     {"SORTIEREN", "TRIER", "ORDENAR", "DATI.ORDINA", "CLASSIFICAR", "СОРТ", "SORTEREN"},
+	"SORTBY":                   {"SORTIERENNACH", "TRIERPAR", "ORDENARPOR", "DATI.ORDINA.PER", "CLASSIFICARPOR", "СОРТПО", "SORTEREN.OP"},
+	"SQRT":                     {"WURZEL", "RACINE", "RAIZ", "RADQ", "RAIZ", "КОРЕНЬ", "WORTEL"},
+	"SQRTPI":                   {"WURZELPI", "RACINE.PI", "RAIZ2PI", "RADQ.PI.GRECO", "RAIZPI", "КОРЕНЬПИ", "WORTEL.PI"},
+	"STANDARDIZE":              {"STANDARDISIERUNG", "CENTREE.REDUITE", "NORMALIZACION", "NORMALIZZA", "PADRONIZAR", "НОРМАЛИЗАЦИЯ", "NORMALISEREN"},
+	"STDEV":                    {"STABW", "ECARTYPE", "DESVEST", "DEV.ST", "DESVPAD", "СТАНДОТКЛОН", "STDEV"},
+	"STDEV.P":                  {"STABW.N", "ECARTYPE.PEARSON", "DESVEST.P", "DEV.ST.P", "DESVPAD.P", "СТАНДОТКЛОН.Г", "STDEV.P"},
+	"STDEV.S":                  {"STABW.S", "ECARTYPE.STANDARD", "DESVEST.M", "DEV.ST.C", "DESVPAD.A", "СТАНДОТКЛОН.В", "STDEV.S"},
+	"STDEVA":                   {"STABWA", "STDEVA", "DESVESTA", "DEV.ST.VALORI", "DESVPADA", "СТАНДОТКЛОНА", "STDEVA"},
+	"STDEVP":                   {"STABWN", "ECARTYPEP", "DESVESTP", "DEV.ST.POP", "DESVPADP", "СТАНДОТКЛОНП", "STDEVP"},
+	"STDEVPA":                  {"STABWNA", "STDEVPA", "DESVESTPA", "DEV.ST.POP.VALORI", "DESVPADPA", "СТАНДОТКЛОНПА", "STDEVPA"},
+	"STEYX":                    {"STFEHLERYX", "ERREUR.TYPE.XY", "ERROR.TIPICO.XY", "ERR.STD.YX", "EPADYX", "СТОШYX", "STAND.FOUT.YX"},
+	"STOCKHISTORY":             {"STOCKHISTORY", "STOCKHISTORY", "STOCKHISTORY", "STOCKHISTORY", "STOCKHISTORY", "STOCKHISTORY", "STOCKHISTORY"},
+	"SUBSTITUTE":               {"WECHSELN", "SUBSTITUE", "SUSTITUIR", "SOSTITUISCI", "SUBSTITUIR", "ПОДСТАВИТЬ", "SUBSTITUEREN"},
+	"SUBTOTAL":                 {"TEILERGEBNIS", "SOUS.TOTAL", "SUBTOTALES", "SUBTOTALE", "SUBTOTAL", "ПРОМЕЖУТОЧНЫЕ.ИТОГИ", "SUBTOTAAL"},
+	"SUM":                      {"SUMME", "SOMME", "SUMA", "SOMMA", "SOMA", "СУММ", "SOM"},
+	"SUMIF":                    {"SUMMEWENN", "SOMME.SI", "SUMAR.SI", "SOMMA.SE", "SOMASE", "СУММЕСЛИ", "SOM.ALS"},
+	"SUMIFS":                   {"SUMMEWENNS", "SOMME.SI.ENS", "SUMAR.SI.CONJUNTO", "SOMMA.PIÙ.SE", "SOMASES", "СУММЕСЛИМН", "SOMMEN.ALS"},
+	"SUMPRODUCT":               {"SUMMENPRODUKT", "SOMMEPROD", "SUMAPRODUCTO", "MATR.SOMMA.PRODOTTO", "SOMARPRODUTO", "СУММПРОИЗВ", "SOMPRODUCT"},
+	"SUMSQ":                    {"QUADRATESUMME", "SOMME.CARRES", "SUMA.CUADRADOS", "SOMMA.Q", "SOMAQUAD", "СУММКВ", "KWADRATENSOM"},
+	"SUMX2MY2":                 {"SUMMEX2MY2", "SOMME.X2MY2", "SUMAX2MENOSY2", "SOMMA.DIFF.Q", "SOMAX2DY2", "СУММРАЗНКВ", "SOM.X2MINY2"},
+	"SUMX2PY2":                 {"SUMMEX2PY2", "SOMME.X2PY2", "SUMAX2MASY2", "SOMMA.SOMMA.Q", "SOMAX2SY2", "СУММСУММКВ", "SOM.X2PLUSY2"},
+	"SUMXMY2":                  {"SUMMEXMY2", "SOMME.XMY2", "SUMAXMENOSY2", "SOMMA.Q.DIFF", "SOMAXMY2", "СУММКВРАЗН", "SOM.XMINY.2"},
+	"SWITCH":                   {"ERSTERWERT", "SI.MULTIPLE", "CAMBIAR", "SWITCH", "PARÂMETRO", "ПЕРЕКЛЮЧ", "SCHAKELEN"},
+	"SYD":                      {"DIA", "SYD", "SYD", "AMMORT.ANNUO", "SDA", "АСЧ", "SYD"},
+	"T":                        {"T", "T", "T", "T", "T", "Т", "T"},
+	"T.DIST":                   {"T.VERT", "LOI.STUDENT.N", "DISTR.T.N", "DISTRIB.T.N", "DIST.T", "СТЬЮДЕНТ.РАСП", "T.DIST"},
+	"T.DIST.2T":                {"T.VERT.2S", "LOI.STUDENT.BILATERALE", "DISTR.T.2C", "DISTRIB.T.2T", "DIST.T.BC", "СТЬЮДЕНТ.РАСП.2Х", "T.DIST.2T"},
+	"T.DIST.RT":                {"T.VERT.RE", "LOI.STUDENT.DROITE", "DISTR.T.CD", "DISTRIB.T.DS", "DIST.T.CD", "СТЬЮДЕНТ.РАСП.ПХ", "T.DIST.RECHTS"},
+	"T.INV":                    {"T.INV", "LOI.STUDENT.INVERSE.N", "INV.T", "INVT", "INV.T", "СТЬЮДЕНТ.ОБР", "T.INV"},
+	"T.INV.2T":                 {"T.INV.2S", "LOI.STUDENT.INVERSE.BILATERALE", "INV.T.2C", "INV.T.2T", "INV.T.BC", "СТЬЮДЕНТ.ОБР.2Х", "T.INV.2T"},
+	"T.TEST":                   {"T.TEST", "T.TEST", "PRUEBA.T.N", "TESTT", "TESTE.T", "СТЬЮДЕНТ.ТЕСТ", "T.TEST"},
+	"TAKE":                     {"ÜBERNEHMEN", "PRENDRE", "TOMAR", "PRENDI", "TOMAR", "ВЗЯТЬ", "NEMEN"},
+	"TAN":                      {"TAN", "TAN", "TAN", "TAN", "TAN", "TAN", "TAN"},
+	"TANH":                     {"TANHYP", "TANH", "TANH", "TANH", "TANH", "TANH", "TANH"},
+	"TBILLEQ":                  {"TBILLÄQUIV", "TAUX.ESCOMPTE.R", "LETRA.DE.TES.EQV.A.BONO", "BOT.EQUIV", "OTN", "РАВНОКЧЕК", "SCHATK.OBL"},
+	"TBILLPRICE":               {"TBILLKURS", "PRIX.BON.TRESOR", "LETRA.DE.TES.PRECIO", "BOT.PREZZO", "OTNVALOR", "ЦЕНАКЧЕК", "SCHATK.PRIJS"},
+	"TBILLYIELD":               {"TBILLRENDITE", "RENDEMENT.BON.TRESOR", "LETRA.DE.TES.RENDTO", "BOT.REND", "OTNLUCRO", "ДОХОДКЧЕК", "SCHATK.REND"},
+	"TDIST":                    {"TVERT", "LOI.STUDENT", "DISTR.T", "DISTRIB.T", "DISTT", "СТЬЮДРАСП", "T.VERD"},
+	"TEXT":                     {"TEXT", "TEXTE", "TEXTO", "TESTO", "TEXTO", "ТЕКСТ", "TEKST"},
+	"TEXTAFTER":                {"TEXTNACH", "TEXTE.APRES", "TEXTODESPUES", "TESTO.DOPO", "TEXTODEPOIS", "ТЕКСТПОСЛЕ", "TEKST.NA"},
+	"TEXTBEFORE":               {"TEXTVOR", "TEXTE.AVANT", "TEXTOANTES", "TESTO.PRIMA", "TEXTOANTES", "ТЕКСТДО", "TEKST.VOOR"},
+	"TEXTJOIN":                 {"TEXTVERKETTEN", "JOINDRE.TEXTE", "UNIRCADENAS", "TESTO.UNISCI", "UNIRTEXTO", "ОБЪЕДИНИТЬ", "TEKST.COMBINEREN"},
+	"TEXTSPLIT":                {"TEXTTEILEN", "FRACTIONNER.TEXTE", "DIVIDIRTEXTO", "DIVIDI.TESTO", "DIVIDIRTEXTO", "ТЕКСТРАЗД", "TEKST.SPLITSEN"},
+	"TIME":                     {"ZEIT", "TEMPS", "NSHORA", "ORARIO", "TEMPO", "ВРЕМЯ", "TIJD"},
+	"TIMEVALUE":                {"ZEITWERT", "TEMPSVAL", "HORANUMERO", "ORARIO.VALORE", "VALOR.TEMPO", "ВРЕМЗНАЧ", "TIJDWAARDE"},
+	"TINV":                     {"TINV", "LOI.STUDENT.INVERSE", "DISTR.T.INV", "INV.T", "INVT", "СТЬЮДРАСПОБР", "TINV"},
+	"TOCOL":                    {"ZUSPALTE", "TOCOL", "TOCOL", "TOCOL", "TOCOL", "TOCOL", "TOCOL"},
+	"TODAY":                    {"HEUTE", "AUJOURDHUI", "HOY", "OGGI", "HOJE", "СЕГОДНЯ", "VANDAAG"},
+	"TOROW":                    {"ZUZEILE", "TOROW", "TOROW", "TOROW", "TOROW", "TOROW", "TOROW"},
+	"TRANSLATE":                {"TRANSLATE", "TRANSLATE", "TRANSLATE", "TRANSLATE", "TRANSLATE", "TRANSLATE", "TRANSLATE"},
+	"TRANSPOSE":                {"MTRANS", "TRANSPOSE", "TRANSPONER", "MATR.TRASPOSTA", "TRANSPOR", "ТРАНСП", "TRANSPONEREN"},
+	"TREND":                    {"TREND", "TENDANCE", "TENDENCIA", "TENDENZA", "TENDÊNCIA", "ТЕНДЕНЦИЯ", "TREND"},
+	"TRIM":                     {"GLÄTTEN", "SUPPRESPACE", "ESPACIOS", "ANNULLA.SPAZI", "ARRUMAR", "СЖПРОБЕЛЫ", "SPATIES.WISSEN"},
+	"TRIMMEAN":                 {"GESTUTZTMITTEL", "MOYENNE.REDUITE", "MEDIA.ACOTADA", "MEDIA.TRONCATA", "MÉDIA.INTERNA", "УРЕЗСРЕДНЕЕ", "GETRIMD.GEM"},
+	"TRIMRANGE":                {"TRIMRANGE", "TRIMRANGE", "TRIMRANGE", "TRIMRANGE", "TRIMRANGE", "TRIMRANGE", "TRIMRANGE"},
+	"TRUE":                     {"WAHR", "VRAI", "VERDADERO", "VERO", "VERDADEIRO", "ИСТИНА", "WAAR"},
+	"TRUNC":                    {"KÜRZEN", "TRONQUE", "TRUNCAR", "TRONCA", "TRUNCAR", "ОТБР", "GEHEEL"},
+	"TTEST":                    {"TTEST", "TEST.STUDENT", "PRUEBA.T", "TEST.T", "TESTET", "ТТЕСТ", "T.TOETS"},
+	"TYPE":                     {"TYP", "TYPE", "TIPO", "TIPO", "TIPO", "ТИП", "TYPE"},
+	"UNICHAR":                  {"UNIZEICHEN", "UNICAR", "UNICAR", "CARATT.UNI", "CARACTUNICODE", "ЮНИСИМВ", "UNITEKEN"},
+	"UNICODE":                  {"UNICODE", "UNICODE", "UNICODE", "UNICODE", "UNICODE", "UNICODE", "UNICODE"},
+	"UNIQUE":                   {"EINDEUTIG", "UNIQUE", "UNICOS", "UNICI", "ÚNICO", "УНИК", "UNIEK"},
+	"UPPER":                    {"GROSS", "MAJUSCULE", "MAYUSC", "MAIUSC", "MAIÚSCULA", "ПРОПИСН", "HOOFDLETTERS"},
+	"VALUE":                    {"WERT", "CNUM", "VALOR", "VALORE", "VALOR", "ЗНАЧЕН", "WAARDE"},
+	"VALUETOTEXT":              {"WERTZUTEXT", "VALEUR.EN.TEXTE", "VALORATEXTO", "VALORE.A.TESTO", "VALORPARATEXTO", "ЗНАЧЕНИЕВТЕКСТ", "WAARDE.NAAR.TEKST"},
+	"VAR":                      {"VARIANZ", "VAR", "VAR", "VAR", "VAR", "ДИСП", "VAR"},
+	"VAR.P":                    {"VAR.P", "VAR.P.N", "VAR.P", "VAR.P", "VAR.P", "ДИСП.Г", "VAR.P"},
+	"VAR.S":                    {"VAR.S", "VAR.S", "VAR.S", "VAR.C", "VAR.A", "ДИСП.В", "VAR.S"},
+	"VARA":                     {"VARIANZA", "VARA", "VARA", "VAR.VALORI", "VARA", "ДИСПА", "VARA"},
+	"VARP":                     {"VARIANZEN", "VAR.P", "VARP", "VAR.POP", "VARP", "ДИСПР", "VARP"},
+	"VARPA":                    {"VARIANZENA", "VARPA", "VARPA", "VAR.POP.VALORI", "VARPA", "ДИСПРА", "VARPA"},
+	"VDB":                      {"VDB", "VDB", "DVS", "AMMORT.VAR", "BDV", "ПУО", "VDB"},
+	"VLOOKUP":                  {"SVERWEIS", "RECHERCHEV", "BUSCARV", "CERCA.VERT", "PROCV", "ВПР", "VERT.ZOEKEN"},
+	"VSTACK":                   {"VSTAPELN", "ASSEMB.V", "APILARV", "STACK.VERT", "EMPILHARV", "ВСТОЛБИК", "VSTACK"},
+	"WEBSERVICE":               {"WEBDIENST", "SERVICEWEB", "SERVICIOWEB", "SERVIZIO.WEB", "SERVIÇOWEB", "ВЕБСЛУЖБА", "WEBSERVICE"},
+	"WEEKDAY":                  {"WOCHENTAG", "JOURSEM", "DIASEM", "GIORNO.SETTIMANA", "DIA.DA.SEMANA", "ДЕНЬНЕД", "WEEKDAG"},
+	"WEEKNUM":                  {"KALENDERWOCHE", "NO.SEMAINE", "NUM.DE.SEMANA", "NUM.SETTIMANA", "NÚMSEMANA", "НОМНЕДЕЛИ", "WEEKNUMMER"},
+	"WEIBULL":                  {"WEIBULL", "LOI.WEIBULL", "DIST.WEIBULL", "WEIBULL", "WEIBULL", "ВЕЙБУЛЛ", "WEIBULL"},
+	"WEIBULL.DIST":             {"WEIBULL.VERT", "LOI.WEIBULL.N", "DISTR.WEIBULL", "DISTRIB.WEIBULL", "DIST.WEIBULL", "ВЕЙБУЛЛ.РАСП", "WEIBULL.VERD"},
+	"WORKDAY":                  {"ARBEITSTAG", "SERIE.JOUR.OUVRE", "DIA.LAB", "GIORNO.LAVORATIVO", "DIATRABALHO", "РАБДЕНЬ", "WERKDAG"},
+	"WORKDAY.INTL":             {"ARBEITSTAG.INTL", "SERIE.JOUR.OUVRE.INTL", "DIA.LAB.INTL", "GIORNO.LAVORATIVO.INTL", "DIATRABALHO.INTL", "РАБДЕНЬ.МЕЖД", "WERKDAG.INTL"},
+	"WRAPCOLS":                 {"UMBRUCHSPALTEN", "WRAPCOLS", "WRAPCOLS", "WRAPCOLS", "WRAPCOLS", "WRAPCOLS", "WRAPCOLS"},
+	"WRAPROWS":                 {"UMBRUCHZEILEN", "WRAPROWS", "WRAPROWS", "WRAPROWS", "WRAPROWS", "WRAPROWS", "WRAPROWS"},
+	"XIRR":                     {"XINTZINSFUSS", "TRI.PAIEMENTS", "TIR.NO.PER", "TIR.X", "XTIR", "ЧИСТВНДОХ", "IR.SCHEMA"},
+	"XLOOKUP":                  {"XVERWEIS", "RECHERCHEX", "BUSCARX", "CERCA.X", "PROCX", "ПРОСМОТРX", "X.ZOEKEN"},
+	"XMATCH":                   {"XVERGLEICH", "EQUIVX", "COINCIDIRX", "CONFRONTA.X", "CORRESPX", "ПОИСКПОЗX", "X.VERGELIJKEN"},
+	"XNPV":                     {"XKAPITALWERT", "VAN.PAIEMENTS", "VNA.NO.PER", "VAN.X", "XVPL", "ЧИСТНЗ", "NHW2"},
+	"XOR":                      {"XODER", "OUX", "XO", "XOR", "OUEXCL", "ИСКЛИЛИ", "EX.OF"},
+	"YEAR":                     {"JAHR", "ANNEE", "AÑO", "ANNO", "ANO", "ГОД", "JAAR"},
+	"YEARFRAC":                 {"BRTEILJAHRE", "FRACTION.ANNEE", "FRAC.AÑO", "FRAZIONE.ANNO", "FRAÇÃOANO", "ДОЛЯГОДА", "JAAR.DEEL"},
+	"YIELD":                    {"RENDITE", "RENDEMENT.TITRE", "RENDTO", "REND", "LUCRO", "ДОХОД", "RENDEMENT"},
+	"YIELDDISC":                {"RENDITEDIS", "RENDEMENT.SIMPLE", "RENDTO.DESC", "REND.TITOLI.SCONT", "LUCRODESC", "ДОХОДСКИДКА", "REND.DISCONTO"},
+	"YIELDMAT":                 {"RENDITEFÄLL", "RENDEMENT.TITRE.ECHEANCE", "RENDTO.VENCTO", "REND.SCAD", "LUCROVENC", "ДОХОДПОГАШ", "REND.VERVAL"},
+	"Z.TEST":                   {"G.TEST", "Z.TEST", "PRUEBA.Z.N", "TESTZ", "TESTE.Z", "Z.ТЕСТ", "Z.TEST"},
+	"ZTEST":                    {"GTEST", "TEST.Z", "PRUEBA.Z", "TEST.Z", "TESTEZ", "ZТЕСТ", "Z.TOETS"},
+}
+
+// errorTranslations maps the English error values to their localized values
+// in the order of the translation languages.
+var errorTranslations = map[string][7]string{
+	FormulaErrorNULL:  {"#NULL!", "#NUL!", "#¡NULO!", "#NULLO!", "#NULO!", "#ПУСТО!", "#LEEG!"},
+	FormulaErrorDIV:   {"#DIV/0!", "#DIV/0!", "#¡DIV/0!", "#DIV/0!", "#DIV/0!", "#ДЕЛ/0!", "#DEEL/0!"},
+	FormulaErrorVALUE: {"#WERT!", "#VALEUR!", "#¡VALOR!", "#VALORE!", "#VALOR!", "#ЗНАЧ!", "#WAARDE!"},
+	FormulaErrorREF:   {"#BEZUG!", "#REF!", "#¡REF!", "#RIF!", "#REF!", "#ССЫЛКА!", "#VERW!"},
+	FormulaErrorNAME:  {"#NAME?", "#NOM?", "#¿NOMBRE?", "#NOME?", "#NOME?", "#ИМЯ?", "#NAAM?"},
+	FormulaErrorNUM:   {"#ZAHL!", "#NOMBRE!", "#¡NUM!", "#NUM!", "#NÚM!", "#ЧИСЛО!", "#GETAL!"},
+	FormulaErrorNA:    {"#NV", "#N/A", "#N/A", "#N/D", "#N/D", "#Н/Д", "#N/B"},
+	FormulaErrorSPILL: {"#ÜBERLAUF!", "#PROPAGATION!", "#¡DESBORDAMIENTO!", "#ESPANSIONE!", "#DESPEJAR!", "#ПЕРЕНОС!", "#OVERLOOP!"},
+	FormulaErrorCALC:  {"#KALK!", "#CALC!", "#¡CALC!", "#CALC!", "#CALC!", "#ВЫЧИСЛ!", "#CALC!"},
+}
+
+// logicalValues is the English logical values, which are translated as the
+// TRUE and FALSE functions.
+var logicalValues = map[string]bool{"TRUE": true, "FALSE": true}
+
+// functionNames maps each language to the lookup tables from the English
+// function names to the localized names and back.
+var functionNames = buildNames(functionTranslations)
+
+// errorNames maps each language to the lookup tables from the English error
+// values to the localized values and back.
+var errorNames = buildNames(errorTranslations)
+
+// localizedErrors is the localized error values recognized by the
+// tokenizer.
+var localizedErrors = buildLocalizedErrors()
+
+// localizedNames directly maps the name lookup tables of a language.
+type localizedNames struct {
+	local   map[string]string
+	english map[string]string
+}
+
+// buildNames provides function to build the name lookup tables of the
+// translation languages from a translation table.
+func buildNames(translations map[string][7]string) map[string]localizedNames {
+	names := make(map[string]localizedNames, len(translationLanguages))
+	for i, language := range translationLanguages {
+		table := localizedNames{
+			local:   make(map[string]string, len(translations)),
+			english: make(map[string]string, len(translations)),
+		}
+		for name, local := range translations {
+			table.local[name], table.english[local[i]] = local[i], name
+		}
+		names[language] = table
+	}
+	return names
+}
+
+// buildLocalizedErrors provides function to build the set of the localized
+// error values which differ from the English ones.
+func buildLocalizedErrors() map[string]bool {
+	errors := make(map[string]bool)
+	for name, local := range errorTranslations {
+		for _, value := range local {
+			if value != name {
+				errors[value] = true
+			}
+		}
+	}
+	return errors
+}
+
+// translateName returns the name in the target language by the lookup
+// tables, and whether the name has a translation.
+func translateName(names map[string]localizedNames, name, from, to string) (string, bool) {
+	english := name
+	if from != LanguageEnglish {
+		var ok bool
+		if english, ok = names[from].english[name]; !ok {
+			return name, false
+		}
+	}
+	if to == LanguageEnglish {
+		return english, true
+	}
+	local, ok := names[to].local[english]
+	if !ok {
+		return name, false
+	}
+	return local, true
+}
+
+// translateFunction returns the function name in the target language, the
+// name is kept when it has no translation.
+func translateFunction(name, from, to string) string {
+	prefix := functionPrefix(name)
+	translated, ok := translateName(functionNames, strings.ToUpper(name[len(prefix):]), from, to)
+	if !ok {
+		return name
+	}
+	if to == LanguageEnglish {
+		return prefix + translated
+	}
+	return translated
+}
+
+// translateLogical returns the logical value in the target language, and
+// whether the operand is a logical value of the source language.
+func translateLogical(value, from, to string) (string, bool) {
+	translated, ok := translateName(functionNames, strings.ToUpper(value), from, LanguageEnglish)
+	if !ok || !logicalValues[translated] {
+		return value, false
+	}
+	return translateName(functionNames, translated, LanguageEnglish, to)
+}
+
+// TranslateFunctions provides function to translate the function names,
+// logical values and error values of a token stream between the languages,
+// such as SUMME in German to SUM in English and WAHR to TRUE. The names
+// without translation are kept, the "_xlfn." prefix is removed for the
+// localized names. The localized logical values are operands of the name
+// subtype as they're parsed.
+func TranslateFunctions(tokens []Token, from, to string) ([]Token, error) {
+	for _, language := range []string{from, to} {
+		if _, ok := functionNames[language]; !ok && language != LanguageEnglish {
+			return nil, fmt.Errorf("unsupported language %q", language)
+		}
+	}
+	result := make([]Token, len(tokens))
+	copy(result, tokens)
+	if from == to {
+		return result, nil
+	}
+	for i, t := range result {
+		switch {
+		case t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStart && t.TValue != "ARRAY" && t.TValue != "ARRAYROW":
+			result[i].TValue = translateFunction(t.TValue, from, to)
+		case t.TType == TokenTypeOperand && t.TSubType == TokenSubTypeError:
+			result[i].TValue, _ = translateName(errorNames, t.TValue, from, to)
+		case t.TType == TokenTypeOperand && (t.TSubType == TokenSubTypeLogical && from == LanguageEnglish ||
+			t.TSubType == TokenSubTypeName && from != LanguageEnglish):
+			value, ok := translateLogical(t.TValue, from, to)
+			if !ok {
+				continue
+			}
+			result[i].TValue, result[i].TSubType = value, TokenSubTypeName
+			if to == LanguageEnglish {
+				result[i].TSubType = TokenSubTypeLogical
+			}
+		}
+	}
+	return result, nil
+}
+
+// RenderLocalized provides function to get the formula text after parsed
+// with the function names of the language and the separators of the locale,
+// such as SUMME(A1;B1) for German.
+func (ps *Parser) RenderLocalized(language string, locale Locale) (string, error) {
+	tokens, err := TranslateFunctions(ps.Tokens.Items, LanguageEnglish, language)
+	if err != nil {
+		return "", err
+	}
+	return renderTokens(tokens, locale.normalize()), nil
+}
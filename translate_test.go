@@ -0,0 +1,71 @@
+package efp
+
+import "testing"
+
+func TestTranslateFunctions(t *testing.T) {
+	for _, language := range translationLanguages {
+		if len(functionNames[language].english) != len(functionTranslations) {
+			t.Errorf("%s: duplicated localized function names", language)
+		}
+		if len(errorNames[language].english) != len(errorTranslations) {
+			t.Errorf("%s: duplicated localized error values", language)
+		}
+	}
+	for _, s := range Functions() {
+		if _, ok := functionTranslations[s.Name]; !ok {
+			t.Errorf("%s: expected translation", s.Name)
+		}
+	}
+	for _, c := range []struct {
+		formula, language, expected string
+		locale                      Locale
+	}{
+		{`=SUMME(A1;WENN(B1>0,5;B1;0))`, LanguageGerman, `SUM(A1,IF(B1>0.5,B1,0))`, LocaleEuropean},
+		{`=ZÄHLENWENN(A:A;"x")+sverweis(1;B:C;2;FALSCH)`, LanguageGerman, `COUNTIF(A:A,"x")+VLOOKUP(1,B:C,2,FALSE)`, LocaleEuropean},
+		{`=WENNFEHLER(#NV;Wahr)+TEILERGEBNIS(9;A1:A10)`, LanguageGerman, `IFERROR(#N/A,TRUE)+SUBTOTAL(9,A1:A10)`, LocaleEuropean},
+		{`=SI(A1=#VALEUR!;FAUX;#N/A)`, LanguageFrench, `IF(A1=#VALUE!,FALSE,#N/A)`, LocaleEuropean},
+		{`=ЕСЛИ(ИСТИНА;#ДЕЛ/0!;ЛОЖЬ)`, LanguageRussian, `IF(TRUE,#DIV/0!,FALSE)`, LocaleEuropean},
+		{`=СУММ(A1:A3)`, LanguageRussian, `SUM(A1:A3)`, LocaleEuropean},
+		{`=SOMME.SI(A1:A3;">0")*MYFUNC(1)`, LanguageFrench, `SUMIF(A1:A3,">0")*MYFUNC(1)`, LocaleEuropean},
+		{`=ROUND(1.5,0)`, LanguageEnglish, `ROUND(1.5,0)`, LocaleEnglish},
+	} {
+		p := ExcelParser(Options{Locale: c.locale})
+		tokens, err := TranslateFunctions(p.Parse(c.formula), c.language, LanguageEnglish)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := renderTokens(tokens, LocaleEnglish); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+	}
+	p := ExcelParser()
+	p.Parse(`=IF(TRUE,#N/A,_xlfn.STDEV.S(A1:A3))`)
+	if actual, err := p.RenderLocalized(LanguageGerman, LocaleEuropean); err != nil || actual != `WENN(WAHR;#NV;STABW.S(A1:A3))` {
+		t.Errorf("expected WENN(WAHR;#NV;STABW.S(A1:A3)), got %s, %v", actual, err)
+	}
+	p.Parse(`=IF(_xlfn.XLOOKUP(1,A:A,B:B)>0.5,{1,2;3,4},ROUNDUP(A1,0))`)
+	for language, expected := range map[string]string{
+		LanguageGerman:     `WENN(XVERWEIS(1;A:A;B:B)>0,5;{1.2;3.4};AUFRUNDEN(A1;0))`,
+		LanguageSpanish:    `SI(BUSCARX(1;A:A;B:B)>0,5;{1.2;3.4};REDONDEAR.MAS(A1;0))`,
+		LanguageItalian:    `SE(CERCA.X(1;A:A;B:B)>0,5;{1.2;3.4};ARROTONDA.PER.ECC(A1;0))`,
+		LanguagePortuguese: `SE(PROCX(1;A:A;B:B)>0,5;{1.2;3.4};ARREDONDAR.PARA.CIMA(A1;0))`,
+		LanguageDutch:      `ALS(X.ZOEKEN(1;A:A;B:B)>0,5;{1.2;3.4};AFRONDEN.NAAR.BOVEN(A1;0))`,
+	} {
+		actual, err := p.RenderLocalized(language, LocaleEuropean)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != expected {
+			t.Errorf("%s: expected %s, got %s", language, expected, actual)
+		}
+	}
+	if actual, err := p.RenderLocalized(LanguageEnglish, Locale{}); err != nil || actual != p.Render() {
+		t.Errorf("expected %s, got %s, %v", p.Render(), actual, err)
+	}
+	if tokens, err := TranslateFunctions(p.Tokens.Items, LanguageGerman, LanguageFrench); err != nil || tokens[0].TValue != "IF" {
+		t.Errorf("expected IF, got %v, %v", tokens, err)
+	}
+	if _, err := p.RenderLocalized("xx", LocaleEnglish); err == nil {
+		t.Error("expected error")
+	}
+}
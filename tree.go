@@ -0,0 +1,378 @@
+package efp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator precedence levels, from the lowest to the highest. Reference
+// operators bind tighter than negation, which binds tighter than percent,
+// exponentiation, multiplication and division, addition and subtraction,
+// concatenation and comparison in that order.
+const (
+	precComparison = iota + 1
+	precConcatenation
+	precAdditive
+	precMultiplicative
+	precExponent
+	precPostfix
+	precPrefix
+	precUnion
+	precIntersection
+)
+
+// Node is implemented by all nodes of the abstract syntax tree built by the
+// BuildTree function.
+type Node interface {
+	// String returns the formula text represented by the node.
+	String() string
+	node()
+}
+
+// Operand node represents a number, text, logical, error or range operand.
+type Operand struct {
+	Token Token
+}
+
+// UnaryOp node represents a prefix operator applied to an operand.
+type UnaryOp struct {
+	Operator Token
+	Operand  Node
+}
+
+// PostfixOp node represents a postfix operator, such as percent, applied to
+// an operand.
+type PostfixOp struct {
+	Operator Token
+	Operand  Node
+}
+
+// BinaryOp node represents an infix operator applied to two operands.
+type BinaryOp struct {
+	Operator Token
+	Left     Node
+	Right    Node
+}
+
+// FunctionCall node represents a function call. An omitted argument, such as
+// the second one in IF(A1,,1), is represented by a nil node.
+type FunctionCall struct {
+	Name      string
+	Token     Token
+	Arguments []Node
+}
+
+// Subexpression node represents a parenthesized expression.
+type Subexpression struct {
+	Expression Node
+}
+
+// ArrayLiteral node represents an array constant, such as {1,2;3,4}.
+type ArrayLiteral struct {
+	Rows [][]Node
+}
+
+func (*Operand) node()       {}
+func (*UnaryOp) node()       {}
+func (*PostfixOp) node()     {}
+func (*BinaryOp) node()      {}
+func (*FunctionCall) node()  {}
+func (*Subexpression) node() {}
+func (*ArrayLiteral) node()  {}
+
+// String returns the formula text of the operand.
+func (n *Operand) String() string {
+	if n.Token.TSubType == TokenSubTypeText {
+		return string(QuoteDouble) + strings.Replace(n.Token.TValue, `"`, `""`, -1) + string(QuoteDouble)
+	}
+	return n.Token.TValue
+}
+
+// String returns the formula text of the prefix operation.
+func (n *UnaryOp) String() string {
+	return n.Operator.TValue + n.Operand.String()
+}
+
+// String returns the formula text of the postfix operation.
+func (n *PostfixOp) String() string {
+	return n.Operand.String() + n.Operator.TValue
+}
+
+// String returns the formula text of the infix operation.
+func (n *BinaryOp) String() string {
+	if n.Operator.TSubType == TokenSubTypeIntersection {
+		return n.Left.String() + string(Whitespace) + n.Right.String()
+	}
+	return n.Left.String() + n.Operator.TValue + n.Right.String()
+}
+
+// String returns the formula text of the function call.
+func (n *FunctionCall) String() string {
+	var output strings.Builder
+	output.WriteString(n.Name)
+	output.WriteRune(ParenOpen)
+	for i, arg := range n.Arguments {
+		if i > 0 {
+			output.WriteRune(Comma)
+		}
+		if arg != nil {
+			output.WriteString(arg.String())
+		}
+	}
+	output.WriteRune(ParenClose)
+	return output.String()
+}
+
+// String returns the formula text of the subexpression.
+func (n *Subexpression) String() string {
+	return string(ParenOpen) + n.Expression.String() + string(ParenClose)
+}
+
+// String returns the formula text of the array constant.
+func (n *ArrayLiteral) String() string {
+	var output strings.Builder
+	output.WriteRune(BraceOpen)
+	for i, row := range n.Rows {
+		if i > 0 {
+			output.WriteRune(Semicolon)
+		}
+		for j, item := range row {
+			if j > 0 {
+				output.WriteRune(Comma)
+			}
+			output.WriteString(item.String())
+		}
+	}
+	output.WriteRune(BraceClose)
+	return output.String()
+}
+
+// treeBuilder provides a recursive descent parser over a token stream.
+type treeBuilder struct {
+	tokens []Token
+	pos    int
+}
+
+// BuildTree provides function to build an abstract syntax tree from a token
+// stream produced by the Parse function, with Excel operator precedence and
+// left associativity applied to the infix operators.
+func BuildTree(tokens []Token) (Node, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty formula")
+	}
+	tb := treeBuilder{tokens: tokens}
+	node, err := tb.expression(precComparison)
+	if err != nil {
+		return nil, err
+	}
+	if tb.pos < len(tb.tokens) {
+		return nil, tb.unexpected()
+	}
+	return node, nil
+}
+
+// ParseTree provides function to parse formula as an abstract syntax tree,
+// the first syntax diagnostic found in the formula is returned as the error.
+func (ps *Parser) ParseTree(formula string) (Node, error) {
+	tokens, diagnostics := ps.ParseWithErrors(formula)
+	if len(diagnostics) > 0 {
+		return nil, diagnostics[0]
+	}
+	return BuildTree(tokens)
+}
+
+// peek returns the current token, or nil at the end of the stream.
+func (tb *treeBuilder) peek() *Token {
+	if tb.pos < len(tb.tokens) {
+		return &tb.tokens[tb.pos]
+	}
+	return nil
+}
+
+// unexpected returns an error for the current token.
+func (tb *treeBuilder) unexpected() error {
+	if t := tb.peek(); t != nil {
+		return fmt.Errorf("unexpected %s token %q at index %d", t.TType, t.TValue, tb.pos)
+	}
+	return fmt.Errorf("unexpected end of formula")
+}
+
+// infixPrecedence returns the precedence of the given infix operator token.
+func infixPrecedence(t *Token) int {
+	switch t.TSubType {
+	case TokenSubTypeIntersection:
+		return precIntersection
+	case TokenSubTypeUnion:
+		return precUnion
+	case TokenSubTypeLogical:
+		return precComparison
+	case TokenSubTypeConcatenation:
+		return precConcatenation
+	}
+	switch t.TValue {
+	case "^":
+		return precExponent
+	case "*", "/":
+		return precMultiplicative
+	}
+	return precAdditive
+}
+
+// expression parses infix operations with a precedence not lower than the
+// given minimum.
+func (tb *treeBuilder) expression(minPrec int) (Node, error) {
+	var (
+		left Node
+		err  error
+	)
+	if minPrec > precPrefix {
+		left, err = tb.primary()
+	} else {
+		left, err = tb.postfix()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := tb.peek()
+		if t == nil || t.TType != TokenTypeOperatorInfix {
+			return left, nil
+		}
+		prec := infixPrecedence(t)
+		if prec < minPrec {
+			return left, nil
+		}
+		tb.pos++
+		right, err := tb.expression(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryOp{Operator: *t, Left: left, Right: right}
+	}
+}
+
+// postfix parses an operand followed by any number of postfix operators.
+func (tb *treeBuilder) postfix() (Node, error) {
+	node, err := tb.prefix()
+	if err != nil {
+		return nil, err
+	}
+	for t := tb.peek(); t != nil && t.TType == TokenTypeOperatorPostfix; t = tb.peek() {
+		tb.pos++
+		node = &PostfixOp{Operator: *t, Operand: node}
+	}
+	return node, nil
+}
+
+// prefix parses an operand preceded by any number of prefix operators.
+func (tb *treeBuilder) prefix() (Node, error) {
+	t := tb.peek()
+	if t == nil || t.TType != TokenTypeOperatorPrefix {
+		return tb.expression(precUnion)
+	}
+	tb.pos++
+	operand, err := tb.prefix()
+	if err != nil {
+		return nil, err
+	}
+	return &UnaryOp{Operator: *t, Operand: operand}, nil
+}
+
+// primary parses an operand, function call, subexpression or array constant.
+func (tb *treeBuilder) primary() (Node, error) {
+	t := tb.peek()
+	if t == nil {
+		return nil, tb.unexpected()
+	}
+	switch {
+	case t.TType == TokenTypeOperand:
+		tb.pos++
+		return &Operand{Token: *t}, nil
+	case t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStart:
+		if t.TValue == "ARRAY" {
+			return tb.array()
+		}
+		tb.pos++
+		args, err := tb.arguments()
+		if err != nil {
+			return nil, err
+		}
+		return &FunctionCall{Name: t.TValue, Token: *t, Arguments: args}, nil
+	case t.TType == TokenTypeSubexpression && t.TSubType == TokenSubTypeStart:
+		tb.pos++
+		expr, err := tb.expression(precComparison)
+		if err != nil {
+			return nil, err
+		}
+		if err = tb.stop(TokenTypeSubexpression); err != nil {
+			return nil, err
+		}
+		return &Subexpression{Expression: expr}, nil
+	}
+	return nil, tb.unexpected()
+}
+
+// stop consumes a stop token of the given type.
+func (tb *treeBuilder) stop(tokenType string) error {
+	t := tb.peek()
+	if t == nil || t.TType != tokenType || t.TSubType != TokenSubTypeStop {
+		return tb.unexpected()
+	}
+	tb.pos++
+	return nil
+}
+
+// arguments parses the argument separated list of a function call up to and
+// including the function stop token.
+func (tb *treeBuilder) arguments() ([]Node, error) {
+	var args []Node
+	if t := tb.peek(); t != nil && t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStop {
+		tb.pos++
+		return args, nil
+	}
+	for {
+		var arg Node
+		if t := tb.peek(); t != nil && !(t.TType == TokenTypeArgument ||
+			(t.TType == TokenTypeFunction && t.TSubType == TokenSubTypeStop)) {
+			var err error
+			if arg, err = tb.expression(precComparison); err != nil {
+				return nil, err
+			}
+		}
+		args = append(args, arg)
+		t := tb.peek()
+		if t != nil && t.TType == TokenTypeArgument {
+			tb.pos++
+			continue
+		}
+		return args, tb.stop(TokenTypeFunction)
+	}
+}
+
+// array parses an array constant made up of ARRAY and ARRAYROW functions.
+func (tb *treeBuilder) array() (Node, error) {
+	tb.pos++
+	array := &ArrayLiteral{}
+	for {
+		t := tb.peek()
+		if t == nil || t.TType != TokenTypeFunction || t.TSubType != TokenSubTypeStart || t.TValue != "ARRAYROW" {
+			return nil, tb.unexpected()
+		}
+		tb.pos++
+		row, err := tb.arguments()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range row {
+			if item == nil {
+				return nil, fmt.Errorf("empty array element at index %d", tb.pos)
+			}
+		}
+		array.Rows = append(array.Rows, row)
+		if t = tb.peek(); t != nil && t.TType == TokenTypeArgument {
+			tb.pos++
+			continue
+		}
+		return array, tb.stop(TokenTypeFunction)
+	}
+}
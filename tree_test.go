@@ -0,0 +1,80 @@
+package efp
+
+import (
+	"strings"
+	"testing"
+)
+
+// dumpTree returns the S-expression of the given node.
+func dumpTree(n Node) string {
+	switch n := n.(type) {
+	case nil:
+		return "_"
+	case *Operand:
+		return n.String()
+	case *UnaryOp:
+		return "(" + n.Operator.TValue + "u " + dumpTree(n.Operand) + ")"
+	case *PostfixOp:
+		return "(" + n.Operator.TValue + " " + dumpTree(n.Operand) + ")"
+	case *BinaryOp:
+		op := n.Operator.TValue
+		if n.Operator.TSubType == TokenSubTypeIntersection {
+			op = "isect"
+		}
+		return "(" + op + " " + dumpTree(n.Left) + " " + dumpTree(n.Right) + ")"
+	case *FunctionCall:
+		args := make([]string, len(n.Arguments))
+		for i, arg := range n.Arguments {
+			args[i] = dumpTree(arg)
+		}
+		return n.Name + "[" + strings.Join(args, " ") + "]"
+	case *Subexpression:
+		return dumpTree(n.Expression)
+	case *ArrayLiteral:
+		rows := make([]string, len(n.Rows))
+		for i, row := range n.Rows {
+			items := make([]string, len(row))
+			for j, item := range row {
+				items[j] = dumpTree(item)
+			}
+			rows[i] = strings.Join(items, " ")
+		}
+		return "{" + strings.Join(rows, "; ") + "}"
+	}
+	return "?"
+}
+
+func TestParseTree(t *testing.T) {
+	for _, c := range []struct{ formula, expected string }{
+		{`=1+2*3`, `(+ 1 (* 2 3))`},
+		{`=1-2-3`, `(- (- 1 2) 3)`},
+		{`=2^3^2`, `(^ (^ 2 3) 2)`},
+		{`=-2^2`, `(^ (-u 2) 2)`},
+		{`=2^3%`, `(^ 2 (% 3))`},
+		{`=1+2&"a"="3a"`, `(= (& (+ 1 2) "a") "3a")`},
+		{`=-A1 B1`, `(-u (isect A1 B1))`},
+		{`=SUM((A:A,1:1))`, `SUM[(, A:A 1:1)]`},
+		{`=(1+2)*3`, `(* (+ 1 2) 3)`},
+		{`=IF(A1,,SUM())`, `IF[A1 _ SUM[]]`},
+		{`={1,-2;"a",TRUE}`, `{1 (-u 2); "a" TRUE}`},
+		{`=10*2^(2*(1+1))%`, `(* 10 (^ 2 (% (* 2 (+ 1 1)))))`},
+	} {
+		ps := ExcelParser()
+		node, err := ps.ParseTree(c.formula)
+		if err != nil {
+			t.Fatalf("%s: %v", c.formula, err)
+		}
+		if actual := dumpTree(node); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.formula, c.expected, actual)
+		}
+		if actual := "=" + node.String(); actual != c.formula {
+			t.Errorf("expected %s, got %s", c.formula, actual)
+		}
+	}
+	for _, formula := range []string{``, `=SUM(1`, `=(1`, `=1+`, `=()`, `={1,}`, `=1 2 +`} {
+		ps := ExcelParser()
+		if _, err := ps.ParseTree(formula); err == nil {
+			t.Errorf("%s: expected error", formula)
+		}
+	}
+}